@@ -0,0 +1,106 @@
+package aoc
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// Value is any type a part function may return as its answer.
+type Value interface {
+	~int | ~int64 | ~string | *big.Int
+}
+
+// Answer is the result of solving a part. It holds an int64, a *big.Int or a
+// string; the zero Answer means no answer was produced.
+type Answer struct {
+	value any
+}
+
+func Int[T ~int | ~int64](n T) Answer {
+	return Answer{int64(n)}
+}
+
+func Big(n *big.Int) Answer {
+	if n.IsInt64() {
+		return Answer{n.Int64()}
+	}
+	return Answer{new(big.Int).Set(n)}
+}
+
+func Text(s string) Answer {
+	return Answer{s}
+}
+
+// NewAnswer wraps any Value returned by a part function. Named integer and
+// string types are converted by their kind, so a part returning a type like
+// `type Steps int` still gives an integer answer.
+func NewAnswer[T Value](v T) Answer {
+	if n, ok := any(v).(*big.Int); ok {
+		if n == nil {
+			return Answer{}
+		}
+		return Big(n)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int64:
+		return Int(rv.Int())
+	case reflect.String:
+		return Text(rv.String())
+	}
+	return Text(fmt.Sprint(v))
+}
+
+// ParseAnswer reads an answer as printed by String, treating anything that is
+// not an integer as text.
+func ParseAnswer(s string) Answer {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Int(n)
+	}
+	if n, ok := new(big.Int).SetString(s, 10); ok {
+		return Big(n)
+	}
+	return Text(s)
+}
+
+func (a Answer) IsZero() bool {
+	return a.value == nil
+}
+
+// BigInt returns the answer as an integer, reporting false for text answers.
+func (a Answer) BigInt() (*big.Int, bool) {
+	switch v := a.value.(type) {
+	case int64:
+		return big.NewInt(v), true
+	case *big.Int:
+		return new(big.Int).Set(v), true
+	}
+	return nil, false
+}
+
+// Equal compares integers by value regardless of how they are stored.
+func (a Answer) Equal(b Answer) bool {
+	aInt, aOk := a.BigInt()
+	bInt, bOk := b.BigInt()
+	if aOk && bOk {
+		return aInt.Cmp(bInt) == 0
+	}
+	return aOk == bOk && a.String() == b.String()
+}
+
+func (a Answer) String() string {
+	switch v := a.value.(type) {
+	case nil:
+		return ""
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case *big.Int:
+		return v.String()
+	case string:
+		return v
+	}
+	return fmt.Sprint(a.value)
+}
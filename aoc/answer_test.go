@@ -0,0 +1,41 @@
+package aoc
+
+import (
+	"math/big"
+	"testing"
+)
+
+type (
+	steps    int
+	winnings int64
+	label    string
+)
+
+func TestAnswerEqual(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct {
+		name string
+		a    Answer
+		b    Answer
+		want bool
+	}{
+		{name: "int and int64", a: Int(42), b: NewAnswer(int64(42)), want: true},
+		{name: "small big.Int", a: Big(big.NewInt(7)), b: Int(7), want: true},
+		{name: "huge big.Int", a: Big(huge), b: ParseAnswer(huge.String()), want: true},
+		{name: "different ints", a: Int(1), b: Int(2), want: false},
+		{name: "text", a: Text("abc"), b: ParseAnswer("abc"), want: true},
+		{name: "text is not int", a: Text("12"), b: Int(12), want: false},
+		{name: "zero", a: Answer{}, b: Answer{}, want: true},
+		{name: "named int", a: NewAnswer(steps(9)), b: Int(9), want: true},
+		{name: "named int64", a: NewAnswer(winnings(9)), b: Int(9), want: true},
+		{name: "named string", a: NewAnswer(label("abc")), b: Text("abc"), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.want {
+				t.Errorf("%v.Equal(%v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
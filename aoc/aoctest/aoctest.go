@@ -0,0 +1,42 @@
+// Package aoctest checks a day's solver against the examples in its testdata,
+// so that each example answer is written down only once, in its .want file.
+package aoctest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+// Examples solves a part of a registered day for each of its examples that
+// has an answer for the part, and checks the answer. It skips the test when no
+// example has one yet.
+func Examples(t *testing.T, number int, part int) {
+	t.Helper()
+
+	day, err := aoc.Lookup(number)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checked := 0
+	for i, example := range day.Examples {
+		want := example.Answers[part-1]
+		if want.IsZero() {
+			continue
+		}
+		checked += 1
+
+		example := example
+		t.Run(fmt.Sprintf("example%d", i+1), func(t *testing.T) {
+			got, err := day.Solve(part, example.Input)
+			if err != nil || !got.Equal(want) {
+				t.Errorf("part%d() = %v, %v, want %v", part, got, err, want)
+			}
+		})
+	}
+	if checked == 0 {
+		t.Skipf("no example of day %d has an answer for part %d", number, part)
+	}
+}
@@ -0,0 +1,31 @@
+package aoctest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 25,
+		Examples: []aoc.Example{
+			{Input: "a\nb\nc", Answers: [2]aoc.Answer{aoc.Int(3)}},
+			{Input: "abc", Answers: [2]aoc.Answer{aoc.Int(1)}},
+		},
+		New: aoc.Funcs(
+			func(input string) (int, error) { return len(strings.Split(input, "\n")), nil },
+			func(input string) (int, error) { return 0, nil },
+		),
+	})
+}
+
+func TestExamples(t *testing.T) {
+	Examples(t, 25, 1)
+}
+
+func TestExamplesSkipsUnknownAnswers(t *testing.T) {
+	Examples(t, 25, 2)
+	t.Errorf("Examples() did not skip a part without example answers")
+}
@@ -0,0 +1,191 @@
+// Package bench times the parse, part 1 and part 2 stages of registered
+// solvers and compares the timings against a saved baseline.
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"text/tabwriter"
+	"time"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+var Stages = []string{"parse", "part1", "part2"}
+
+type Result struct {
+	Day         int    `json:"day"`
+	Stage       string `json:"stage"`
+	Iterations  int    `json:"iterations"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	AllocsPerOp uint64 `json:"allocs_per_op"`
+	BytesPerOp  uint64 `json:"bytes_per_op"`
+}
+
+func (r Result) key() string {
+	return fmt.Sprintf("%d/%s", r.Day, r.Stage)
+}
+
+// Run times each stage of the day over the given number of iterations. The
+// part stages get a freshly parsed solver every iteration, and only the part
+// itself is measured. The parse stage is left out for days that parse inside
+// each part, where it would only measure the adapter. A stage that fails does
+// not stop the others; the errors of every failed stage are returned together.
+func Run(day aoc.Day, input string, stages []string, iterations int) ([]Result, error) {
+	results := []Result{}
+	errs := []error{}
+	for _, stage := range stages {
+		if stage == "parse" && day.ParsesInParts() {
+			continue
+		}
+		result, err := runStage(day, input, stage, iterations)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("day %d %s: %w", day.Number, stage, err))
+			continue
+		}
+		results = append(results, result)
+	}
+	return results, errors.Join(errs...)
+}
+
+func runStage(day aoc.Day, input string, stage string, iterations int) (result Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panicked: %v", r)
+		}
+	}()
+
+	var elapsed time.Duration
+	var mallocs, bytes uint64
+	var before, after runtime.MemStats
+
+	for i := 0; i < iterations; i++ {
+		solver := day.New()
+
+		var measure func() error
+		switch stage {
+		case "parse":
+			measure = func() error { return solver.Parse(input) }
+		case "part1", "part2":
+			if err := solver.Parse(input); err != nil {
+				return Result{}, err
+			}
+			part := solver.Part1
+			if stage == "part2" {
+				part = solver.Part2
+			}
+			measure = func() error {
+				_, err := part()
+				return err
+			}
+		default:
+			return Result{}, fmt.Errorf("unknown stage %q", stage)
+		}
+
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		err := measure()
+		elapsed += time.Since(start)
+		runtime.ReadMemStats(&after)
+		if err != nil {
+			return Result{}, err
+		}
+
+		mallocs += after.Mallocs - before.Mallocs
+		bytes += after.TotalAlloc - before.TotalAlloc
+	}
+
+	n := max(iterations, 1)
+	return Result{
+		Day:         day.Number,
+		Stage:       stage,
+		Iterations:  iterations,
+		NsPerOp:     elapsed.Nanoseconds() / int64(n),
+		AllocsPerOp: mallocs / uint64(n),
+		BytesPerOp:  bytes / uint64(n),
+	}, nil
+}
+
+// Comparison is a result next to the baseline result for the same stage.
+type Comparison struct {
+	Result
+	Baseline *Result
+	// Slower is set when the result exceeds the baseline by more than the
+	// threshold given to Compare.
+	Slower bool
+}
+
+// Ratio is the time per op relative to the baseline, or 0 without one.
+func (c Comparison) Ratio() float64 {
+	if c.Baseline == nil || c.Baseline.NsPerOp == 0 {
+		return 0
+	}
+	return float64(c.NsPerOp) / float64(c.Baseline.NsPerOp)
+}
+
+// Compare pairs results with the baseline, flagging those more than threshold
+// times slower. A threshold of 1.2 allows for 20% noise.
+func Compare(results []Result, baseline []Result, threshold float64) []Comparison {
+	byKey := make(map[string]Result)
+	for _, b := range baseline {
+		byKey[b.key()] = b
+	}
+
+	comparisons := make([]Comparison, len(results))
+	for i, result := range results {
+		comparisons[i] = Comparison{Result: result}
+		if b, ok := byKey[result.key()]; ok {
+			comparisons[i].Baseline = &b
+			comparisons[i].Slower = comparisons[i].Ratio() > threshold
+		}
+	}
+	return comparisons
+}
+
+// WriteTable prints the comparisons as an aligned summary table.
+func WriteTable(w io.Writer, comparisons []Comparison) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "day\tstage\titers\ttime/op\tallocs/op\tB/op\tvs baseline\t")
+
+	var total time.Duration
+	for _, c := range comparisons {
+		vs := ""
+		if c.Baseline != nil {
+			vs = fmt.Sprintf("%.2fx", c.Ratio())
+			if c.Slower {
+				vs += " SLOWER"
+			}
+		}
+		fmt.Fprintf(tw, "%02d\t%s\t%d\t%v\t%d\t%d\t%s\t\n",
+			c.Day, c.Stage, c.Iterations, time.Duration(c.NsPerOp), c.AllocsPerOp, c.BytesPerOp, vs)
+		total += time.Duration(c.NsPerOp)
+	}
+	fmt.Fprintf(tw, "\ttotal\t\t%v\t\t\t\t\n", total)
+
+	return tw.Flush()
+}
+
+func Load(path string) ([]Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("reading baseline %s: %w", path, err)
+	}
+	return results, nil
+}
+
+func Save(path string, results []Result) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,103 @@
+package bench
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+func TestRun(t *testing.T) {
+	day := aoc.Day{
+		Number: 1,
+		New: aoc.Funcs(
+			func(input string) (int, error) { return len(strings.Fields(input)), nil },
+			func(input string) (int, error) { panic("not solved yet") },
+		),
+	}
+
+	results, err := Run(day, "1 2 3", []string{"parse", "part1"}, 10)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Stage != "part1" || results[0].Iterations != 10 {
+		t.Errorf("Run() = %+v, want only part1 over 10 iterations", results)
+	}
+
+	if _, err := Run(day, "1 2 3", []string{"part2"}, 1); err == nil {
+		t.Errorf("Run() error = nil, want the panic in part2 reported")
+	}
+}
+
+type fieldsSolver struct {
+	fields []string
+}
+
+func (s *fieldsSolver) Parse(input string) error {
+	s.fields = strings.Fields(input)
+	return nil
+}
+
+func (s *fieldsSolver) Part1() (aoc.Answer, error) {
+	return aoc.Int(len(s.fields)), nil
+}
+
+func (s *fieldsSolver) Part2() (aoc.Answer, error) {
+	return aoc.Answer{}, errors.New("not solved yet")
+}
+
+func TestRunParsingSolver(t *testing.T) {
+	day := aoc.Day{Number: 2, New: func() aoc.Solver { return &fieldsSolver{} }}
+
+	// part2 fails first, which must not stop part1 from being timed.
+	results, err := Run(day, "1 2 3", []string{"parse", "part2", "part1"}, 1)
+	if err == nil || !strings.Contains(err.Error(), "day 2 part2: not solved yet") {
+		t.Errorf("Run() error = %v, want the error from part2", err)
+	}
+	if len(results) != 2 || results[0].Stage != "parse" || results[1].Stage != "part1" {
+		t.Errorf("Run() = %+v, want parse and part1", results)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []Result{
+		{Day: 1, Stage: "part1", NsPerOp: 100},
+		{Day: 1, Stage: "part2", NsPerOp: 100},
+	}
+	results := []Result{
+		{Day: 1, Stage: "part1", NsPerOp: 110},
+		{Day: 1, Stage: "part2", NsPerOp: 200},
+		{Day: 2, Stage: "part1", NsPerOp: 500},
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := Save(path, baseline); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	comparisons := Compare(results, loaded, 1.2)
+	slower := []bool{false, true, false}
+	for i, c := range comparisons {
+		if c.Slower != slower[i] {
+			t.Errorf("day %d %s Slower = %v, want %v", c.Day, c.Stage, c.Slower, slower[i])
+		}
+	}
+	if comparisons[2].Baseline != nil {
+		t.Errorf("day 2 has a baseline, want none")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, comparisons); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "2.00x SLOWER") {
+		t.Errorf("table does not flag the slowdown:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,260 @@
+// Package client talks to the Advent of Code website on behalf of a logged in
+// user, identified by the session cookie from their browser.
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBaseURL = "https://adventofcode.com"
+	// DefaultInterval is the minimum time between two requests to the site,
+	// shared by every aoc process through the cache directory.
+	DefaultInterval = 5 * time.Second
+	userAgent       = "github.com/basokant/advent-of-code-2023"
+	// SessionEnv holds the session cookie. When it is unset the cookie is read
+	// from the file returned by SessionFile.
+	SessionEnv = "AOC_SESSION"
+)
+
+var ErrNoSession = errors.New("no session cookie: set " + SessionEnv + " or save it to the session file")
+
+type Client struct {
+	BaseURL string
+	Session string
+	HTTP    *http.Client
+	// CacheDir stores downloaded inputs and the time of the last request.
+	// Caching and cross-process rate limiting are disabled when it is empty.
+	CacheDir string
+	// StateDir stores files that must outlive a cleared cache, such as the
+	// record of submitted answers.
+	StateDir string
+	Interval time.Duration
+
+	mu    sync.Mutex
+	last  time.Time
+	now   func() time.Time
+	sleep func(context.Context, time.Duration) error
+}
+
+// New returns a client for the real site that caches under the user's cache
+// directory and keeps its state under the user's config directory.
+func New(session string) (*Client, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("finding cache directory: %w", err)
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("finding config directory: %w", err)
+	}
+
+	return &Client{
+		BaseURL:  DefaultBaseURL,
+		Session:  session,
+		HTTP:     &http.Client{Timeout: 30 * time.Second},
+		CacheDir: filepath.Join(cacheDir, "aoc"),
+		StateDir: filepath.Join(configDir, "aoc"),
+		Interval: DefaultInterval,
+	}, nil
+}
+
+// SessionFile is where the session cookie is kept when SessionEnv is unset.
+func SessionFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "aoc", "session"), nil
+}
+
+// Session returns the session cookie from SessionEnv or SessionFile.
+func Session() (string, error) {
+	if session := strings.TrimSpace(os.Getenv(SessionEnv)); session != "" {
+		return session, nil
+	}
+
+	path, err := SessionFile()
+	if err != nil {
+		return "", ErrNoSession
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoSession
+	} else if err != nil {
+		return "", fmt.Errorf("reading session file: %w", err)
+	}
+
+	session := strings.TrimSpace(string(data))
+	if session == "" {
+		return "", ErrNoSession
+	}
+	return session, nil
+}
+
+// Input returns the puzzle input for a day, from the cache when possible.
+func (c *Client) Input(ctx context.Context, year int, day int) (string, error) {
+	cachePath := c.cachePath(year, fmt.Sprintf("day%02d-input.txt", day))
+	if cachePath != "" {
+		if data, err := os.ReadFile(cachePath); err == nil {
+			return string(data), nil
+		}
+	}
+
+	body, err := c.get(ctx, fmt.Sprintf("/%d/day/%d/input", year, day))
+	if err != nil {
+		return "", err
+	}
+
+	if cachePath != "" {
+		if err := writeFile(cachePath, body); err != nil {
+			return "", fmt.Errorf("caching input: %w", err)
+		}
+	}
+	return string(body), nil
+}
+
+// cachePath returns where a file for the current user is cached, keyed by a
+// hash of the session so that several accounts can share a cache directory.
+func (c *Client) cachePath(year int, name string) string {
+	return c.userPath(c.CacheDir, year, name)
+}
+
+// userPath returns where a file for the current user and year is kept under
+// dir, or "" if dir is empty. Users are told apart by a hash of the session.
+func (c *Client) userPath(dir string, year int, name string) string {
+	if dir == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(c.Session))
+	user := hex.EncodeToString(sum[:8])
+	return filepath.Join(dir, user, strconv.Itoa(year), name)
+}
+
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	if err := c.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%s: not found, the puzzle may not be unlocked yet", req.URL.Path)
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized:
+		return nil, fmt.Errorf("%s: %s, the session cookie may have expired", req.URL.Path, resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%s: %s", req.URL.Path, resp.Status)
+	}
+	return body, nil
+}
+
+// wait blocks until Interval has passed since the last request made by any
+// client sharing the cache directory, then records this request.
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now, sleep := c.now, c.sleep
+	if now == nil {
+		now = time.Now
+	}
+	if sleep == nil {
+		sleep = sleepContext
+	}
+
+	stampPath := ""
+	if c.CacheDir != "" {
+		stampPath = filepath.Join(c.CacheDir, "last-request")
+	}
+
+	last := c.last
+	if stampPath != "" {
+		if data, err := os.ReadFile(stampPath); err == nil {
+			if nanos, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil {
+				last = time.Unix(0, max(nanos, last.UnixNano()))
+			}
+		}
+	}
+
+	if delay := last.Add(c.Interval).Sub(now()); delay > 0 {
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+
+	c.last = now()
+	if stampPath != "" {
+		return writeFile(stampPath, []byte(strconv.FormatInt(c.last.UnixNano(), 10)))
+	}
+	return nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Puzzle returns the HTML of a day's puzzle description. It is not cached
+// since the page gains part two once part one is solved.
+func (c *Client) Puzzle(ctx context.Context, year int, day int) (string, error) {
+	body, err := c.get(ctx, fmt.Sprintf("/%d/day/%d", year, day))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
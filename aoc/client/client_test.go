@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client for a stand-in server with a fake clock, so
+// rate limiting can be observed without sleeping.
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *[]time.Duration) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	clock := time.Date(2023, 12, 1, 5, 0, 0, 0, time.UTC)
+	slept := &[]time.Duration{}
+
+	c := &Client{
+		BaseURL:  server.URL,
+		Session:  "secret",
+		HTTP:     server.Client(),
+		CacheDir: t.TempDir(),
+		Interval: 5 * time.Second,
+		now:      func() time.Time { return clock },
+		sleep: func(_ context.Context, d time.Duration) error {
+			*slept = append(*slept, d)
+			clock = clock.Add(d)
+			return nil
+		},
+	}
+	return c, slept
+}
+
+func TestInput(t *testing.T) {
+	requests := 0
+	c, slept := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests += 1
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			http.Error(w, "missing session", http.StatusBadRequest)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/2023/day/1/input":
+			w.Write([]byte("1abc2\n"))
+		case "/2023/day/2/input":
+			w.Write([]byte("Game 1: 3 blue\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		got, err := c.Input(ctx, 2023, 1)
+		if err != nil {
+			t.Fatalf("Input() error = %v", err)
+		}
+		if got != "1abc2\n" {
+			t.Errorf("Input() = %q, want %q", got, "1abc2\n")
+		}
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests, want 1 with the second served from cache", requests)
+	}
+
+	if _, err := c.Input(ctx, 2023, 2); err != nil {
+		t.Fatalf("Input() error = %v", err)
+	}
+	if len(*slept) != 1 || (*slept)[0] != 5*time.Second {
+		t.Errorf("slept %v, want one 5s wait between requests", *slept)
+	}
+
+	if _, err := c.Input(ctx, 2023, 25); err == nil {
+		t.Errorf("Input() error = nil, want not found error")
+	}
+}
+
+func TestInputExpiredSession(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Puzzle inputs differ by user.", http.StatusBadRequest)
+	})
+
+	if _, err := c.Input(context.Background(), 2023, 1); err == nil {
+		t.Errorf("Input() error = nil, want error")
+	}
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type Outcome int
+
+const (
+	Unknown Outcome = iota
+	Correct
+	Incorrect
+	TooHigh
+	TooLow
+	// TooSoon means the answer was not checked because the previous attempt
+	// was too recent.
+	TooSoon
+	// WrongLevel means the part is locked or has already been solved.
+	WrongLevel
+)
+
+var outcomeNames = []string{"unknown", "correct", "incorrect", "too high", "too low", "too soon", "wrong level"}
+
+func (o Outcome) String() string {
+	if 0 <= o && int(o) < len(outcomeNames) {
+		return outcomeNames[o]
+	}
+	return "unknown"
+}
+
+func (o Outcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func (o *Outcome) UnmarshalText(text []byte) error {
+	for i, name := range outcomeNames {
+		if name == string(text) {
+			*o = Outcome(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown outcome %q", text)
+}
+
+// Wrong reports whether the site checked the answer and rejected it.
+func (o Outcome) Wrong() bool {
+	return o == Incorrect || o == TooHigh || o == TooLow
+}
+
+type Result struct {
+	Outcome Outcome
+	// Wait is how long the site asks to wait before the next attempt.
+	Wait time.Duration
+	// Message is the text of the site's response.
+	Message string
+}
+
+var (
+	articleRe = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRe     = regexp.MustCompile(`<[^>]*>`)
+	leftRe    = regexp.MustCompile(`You have ((?:\d+h ?)?(?:\d+m ?)?(?:\d+s)?) left to wait`)
+	minutesRe = regexp.MustCompile(`wait (one|\d+) minutes?`)
+)
+
+// ParseResult reads the page the site returns after submitting an answer.
+func ParseResult(page string) Result {
+	message := page
+	if match := articleRe.FindStringSubmatch(page); match != nil {
+		message = match[1]
+	}
+	message = strings.Join(strings.Fields(html.UnescapeString(tagRe.ReplaceAllString(message, ""))), " ")
+
+	result := Result{Message: message}
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		result.Outcome = Correct
+	case strings.Contains(message, "That's not the right answer"):
+		result.Outcome = Incorrect
+		if strings.Contains(message, "too high") {
+			result.Outcome = TooHigh
+		} else if strings.Contains(message, "too low") {
+			result.Outcome = TooLow
+		}
+	case strings.Contains(message, "You gave an answer too recently"):
+		result.Outcome = TooSoon
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		result.Outcome = WrongLevel
+	}
+
+	if match := leftRe.FindStringSubmatch(message); match != nil {
+		result.Wait, _ = time.ParseDuration(strings.ReplaceAll(match[1], " ", ""))
+	} else if match := minutesRe.FindStringSubmatch(message); match != nil {
+		minutes := 1
+		fmt.Sscan(match[1], &minutes)
+		result.Wait = time.Duration(minutes) * time.Minute
+	}
+
+	return result
+}
+
+// Submit posts an answer for a part and reports the site's verdict.
+func (c *Client) Submit(ctx context.Context, year int, day int, part int, answer string) (Result, error) {
+	form := url.Values{
+		"level":  {fmt.Sprint(part)},
+		"answer": {answer},
+	}
+
+	path := fmt.Sprintf("/%d/day/%d/answer", year, day)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := c.do(req)
+	if err != nil {
+		return Result{}, err
+	}
+	return ParseResult(string(body)), nil
+}
+
+// UserPath returns where a file belonging to the current user is kept for the
+// given year, or "" when the client has no state directory. Unlike the cache,
+// these files are never safe to delete.
+func (c *Client) UserPath(year int, name string) string {
+	return c.userPath(c.StateDir, year, name)
+}
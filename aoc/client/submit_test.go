@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     string
+		want     Outcome
+		wantWait time.Duration
+	}{
+		{
+			name: "correct",
+			page: `<main><article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer.</p></article></main>`,
+			want: Correct,
+		},
+		{
+			name:     "too high",
+			page:     `<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; please wait one minute before trying again. [<a href="/2023/day/5">Return to Day 5</a>]</p></article>`,
+			want:     TooHigh,
+			wantWait: time.Minute,
+		},
+		{
+			name:     "too low",
+			page:     `<article><p>That's not the right answer; your answer is too low.  please wait 5 minutes before trying again.</p></article>`,
+			want:     TooLow,
+			wantWait: 5 * time.Minute,
+		},
+		{
+			name: "wrong",
+			page: `<article><p>That's not the right answer.  If you're stuck, there are some general tips.</p></article>`,
+			want: Incorrect,
+		},
+		{
+			name:     "too soon",
+			page:     `<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 23s left to wait. [<a href="/2023/day/5">Return to Day 5</a>]</p></article>`,
+			want:     TooSoon,
+			wantWait: time.Minute + 23*time.Second,
+		},
+		{
+			name: "wrong level",
+			page: `<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>`,
+			want: WrongLevel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseResult(tt.page)
+			if got.Outcome != tt.want || got.Wait != tt.wantWait {
+				t.Errorf("ParseResult() = %v, %v, want %v, %v", got.Outcome, got.Wait, tt.want, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2023/day/3/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.FormValue("level") != "2" || r.FormValue("answer") != "467835" {
+			http.Error(w, "bad form", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`<article><p>That's the right answer!</p></article>`))
+	})
+
+	got, err := c.Submit(context.Background(), 2023, 3, 2, "467835")
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if got.Outcome != Correct {
+		t.Errorf("Submit() = %v, want %v", got.Outcome, Correct)
+	}
+}
+
+func TestUserPathOutlivesCache(t *testing.T) {
+	c := &Client{Session: "secret", CacheDir: "/cache/aoc", StateDir: "/config/aoc"}
+
+	path := c.UserPath(2023, "ledger.json")
+	if !strings.HasPrefix(path, "/config/aoc/") || !strings.HasSuffix(path, "/2023/ledger.json") {
+		t.Errorf("UserPath() = %q, want under the state directory", path)
+	}
+	if other := (&Client{Session: "other", StateDir: "/config/aoc"}).UserPath(2023, "ledger.json"); other == path {
+		t.Errorf("UserPath() = %q for two sessions", path)
+	}
+	if path := (&Client{Session: "secret", CacheDir: "/cache/aoc"}).UserPath(2023, "ledger.json"); path != "" {
+		t.Errorf("UserPath() without a state directory = %q, want empty", path)
+	}
+}
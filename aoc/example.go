@@ -0,0 +1,110 @@
+package aoc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Example is an example input from a puzzle description together with the
+// answers the description gives for it. Unknown answers are zero.
+type Example struct {
+	Input   string
+	Answers [2]Answer
+}
+
+// Examples are stored as testdata/exampleN.txt, holding the input, next to an
+// optional testdata/exampleN.want listing the expected answers as
+// "part1: 142" lines.
+func exampleFiles(n int) (string, string) {
+	return fmt.Sprintf("example%d.txt", n), fmt.Sprintf("example%d.want", n)
+}
+
+// LoadExamples reads example1, example2, ... from the testdata directory of
+// fsys until one is missing.
+func LoadExamples(fsys fs.FS) ([]Example, error) {
+	examples := []Example{}
+	for n := 1; ; n++ {
+		inputName, wantName := exampleFiles(n)
+
+		input, err := fs.ReadFile(fsys, path.Join("testdata", inputName))
+		if errors.Is(err, fs.ErrNotExist) {
+			return examples, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		example := Example{Input: strings.TrimRight(string(input), "\n")}
+
+		want, err := fs.ReadFile(fsys, path.Join("testdata", wantName))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if example.Answers, err = parseWant(string(want)); err != nil {
+			return nil, fmt.Errorf("%s: %w", wantName, err)
+		}
+
+		examples = append(examples, example)
+	}
+}
+
+// MustLoadExamples is LoadExamples for package-level variables, panicking on
+// error.
+func MustLoadExamples(fsys fs.FS) []Example {
+	examples, err := LoadExamples(fsys)
+	if err != nil {
+		panic(fmt.Sprintf("aoc: loading examples: %v", err))
+	}
+	return examples
+}
+
+func parseWant(want string) ([2]Answer, error) {
+	answers := [2]Answer{}
+
+	scanner := bufio.NewScanner(strings.NewReader(want))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		part, err := strconv.Atoi(strings.TrimPrefix(key, "part"))
+		if !ok || !strings.HasPrefix(key, "part") || err != nil || part < 1 || part > 2 {
+			return answers, fmt.Errorf("line %d: want \"part1: answer\" or \"part2: answer\", got %q", lineNum, line)
+		}
+		answers[part-1] = ParseAnswer(strings.TrimSpace(value))
+	}
+	return answers, scanner.Err()
+}
+
+// WriteExample writes the nth example into dir/testdata in the layout read
+// by LoadExamples.
+func WriteExample(dir string, n int, example Example) error {
+	testdata := filepath.Join(dir, "testdata")
+	if err := os.MkdirAll(testdata, 0o755); err != nil {
+		return err
+	}
+
+	inputName, wantName := exampleFiles(n)
+	if err := os.WriteFile(filepath.Join(testdata, inputName), []byte(example.Input+"\n"), 0o644); err != nil {
+		return err
+	}
+
+	var want strings.Builder
+	for i, answer := range example.Answers {
+		if !answer.IsZero() {
+			fmt.Fprintf(&want, "part%d: %v\n", i+1, answer)
+		}
+	}
+	if want.Len() == 0 {
+		return nil
+	}
+	return os.WriteFile(filepath.Join(testdata, wantName), []byte(want.String()), 0o644)
+}
@@ -0,0 +1,51 @@
+package aoc
+
+import (
+	"os"
+	"testing"
+)
+
+func TestWriteAndLoadExamples(t *testing.T) {
+	dir := t.TempDir()
+	examples := []Example{
+		{Input: "1abc2\npqr3stu8vwx", Answers: [2]Answer{Int(142)}},
+		{Input: "two1nine", Answers: [2]Answer{{}, Int(29)}},
+		{Input: "no answers"},
+	}
+	for i, example := range examples {
+		if err := WriteExample(dir, i+1, example); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := LoadExamples(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("LoadExamples() error = %v", err)
+	}
+	if len(got) != len(examples) {
+		t.Fatalf("LoadExamples() returned %d examples, want %d", len(got), len(examples))
+	}
+	for i, example := range examples {
+		if got[i].Input != example.Input {
+			t.Errorf("example %d input = %q, want %q", i+1, got[i].Input, example.Input)
+		}
+		for part, answer := range example.Answers {
+			if !got[i].Answers[part].Equal(answer) {
+				t.Errorf("example %d part %d = %v, want %v", i+1, part+1, got[i].Answers[part], answer)
+			}
+		}
+	}
+}
+
+func TestLoadExamplesBadWant(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(dir+"/testdata", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(dir+"/testdata/example1.txt", []byte("input\n"), 0o644)
+	os.WriteFile(dir+"/testdata/example1.want", []byte("part3: 1\n"), 0o644)
+
+	if _, err := LoadExamples(os.DirFS(dir)); err == nil {
+		t.Errorf("LoadExamples() error = nil, want error for part3")
+	}
+}
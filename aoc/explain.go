@@ -0,0 +1,46 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+)
+
+// Explainer is implemented by solvers that can show the working behind an
+// answer, such as how each hand of cards was ranked. Parse has always been
+// called before Explain.
+type Explainer interface {
+	Explain(w io.Writer, part int, format ExplainFormat) error
+}
+
+// ExplainFormat is how an explanation is written.
+type ExplainFormat string
+
+const (
+	ExplainTable ExplainFormat = "table"
+	ExplainJSON  ExplainFormat = "json"
+)
+
+func ParseExplainFormat(s string) (ExplainFormat, error) {
+	switch format := ExplainFormat(s); format {
+	case ExplainTable, ExplainJSON:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown explain format %q, want table or json", s)
+}
+
+// Explain writes the working behind the day's answer to part for input.
+func (d Day) Explain(w io.Writer, part int, input string, format ExplainFormat) error {
+	if part != 1 && part != 2 {
+		return fmt.Errorf("day %d has no part %d", d.Number, part)
+	}
+
+	solver := d.New()
+	explainer, ok := solver.(Explainer)
+	if !ok {
+		return fmt.Errorf("day %d cannot explain its answers", d.Number)
+	}
+	if err := solver.Parse(input); err != nil {
+		return fmt.Errorf("day %d: parsing input: %w", d.Number, err)
+	}
+	return explainer.Explain(w, part, format)
+}
@@ -0,0 +1,62 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+type explainingSolver struct {
+	input string
+}
+
+func (s *explainingSolver) Parse(input string) error {
+	s.input = input
+	return nil
+}
+
+func (s *explainingSolver) Part1() (Answer, error) {
+	return Int(len(s.input)), nil
+}
+
+func (s *explainingSolver) Part2() (Answer, error) {
+	return Int(0), nil
+}
+
+func (s *explainingSolver) Explain(w io.Writer, part int, format ExplainFormat) error {
+	_, err := fmt.Fprintf(w, "%s part %d of %q", format, part, s.input)
+	return err
+}
+
+func TestExplain(t *testing.T) {
+	day := Day{Number: 1, New: func() Solver { return &explainingSolver{} }}
+
+	var b strings.Builder
+	if err := day.Explain(&b, 1, "abc", ExplainJSON); err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if got, want := b.String(), `json part 1 of "abc"`; got != want {
+		t.Errorf("Explain() wrote %q, want %q", got, want)
+	}
+
+	if err := day.Explain(&b, 3, "abc", ExplainJSON); err == nil {
+		t.Errorf("Explain(3) error = nil, want error")
+	}
+
+	plain := Day{Number: 2, New: Funcs(func(string) (int, error) { return 0, nil }, func(string) (int, error) { return 0, nil })}
+	if err := plain.Explain(&b, 1, "abc", ExplainTable); err == nil {
+		t.Errorf("Explain() of a solver that cannot explain error = nil, want error")
+	}
+}
+
+func TestParseExplainFormat(t *testing.T) {
+	for _, s := range []string{"table", "json"} {
+		if got, err := ParseExplainFormat(s); err != nil || string(got) != s {
+			t.Errorf("ParseExplainFormat(%q) = %v, %v", s, got, err)
+		}
+	}
+	if _, err := ParseExplainFormat("yaml"); err == nil {
+		t.Errorf("ParseExplainFormat(yaml) error = nil, want error")
+	}
+}
@@ -0,0 +1,88 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Golden holds the accepted answer of each day's parts on the real input,
+// indexed by day. Parts whose answer is not known yet are zero.
+type Golden map[int][2]Answer
+
+// goldenFile is the layout of answers.json:
+//
+//	{"day01": {"part1": "55488", "part2": "55614"}}
+type goldenFile map[string]map[string]string
+
+// LoadGolden reads a golden answers file. A missing file has no answers.
+func LoadGolden(path string) (Golden, error) {
+	golden := Golden{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return golden, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var file goldenFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	for dayKey, parts := range file {
+		day, err := strconv.Atoi(strings.TrimPrefix(dayKey, "day"))
+		if err != nil || !strings.HasPrefix(dayKey, "day") {
+			return nil, fmt.Errorf("reading %s: bad day %q", path, dayKey)
+		}
+
+		answers := [2]Answer{}
+		for partKey, answer := range parts {
+			part, err := strconv.Atoi(strings.TrimPrefix(partKey, "part"))
+			if err != nil || part < 1 || part > 2 {
+				return nil, fmt.Errorf("reading %s: bad part %q of %s", path, partKey, dayKey)
+			}
+			answers[part-1] = ParseAnswer(answer)
+		}
+		golden[day] = answers
+	}
+	return golden, nil
+}
+
+// Answer returns the accepted answer for a part, if it is known.
+func (g Golden) Answer(day int, part int) (Answer, bool) {
+	answer := g[day][part-1]
+	return answer, !answer.IsZero()
+}
+
+// Set records the accepted answer for a part.
+func (g Golden) Set(day int, part int, answer Answer) {
+	answers := g[day]
+	answers[part-1] = answer
+	g[day] = answers
+}
+
+// Save writes the golden answers to path in the layout read by LoadGolden.
+func (g Golden) Save(path string) error {
+	file := goldenFile{}
+	for day, answers := range g {
+		parts := map[string]string{}
+		for i, answer := range answers {
+			if !answer.IsZero() {
+				parts[fmt.Sprintf("part%d", i+1)] = answer.String()
+			}
+		}
+		file[fmt.Sprintf("day%02d", day)] = parts
+	}
+
+	// encoding/json sorts map keys, so the file stays ordered by day.
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,35 @@
+package aoc
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGoldenSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	golden, err := LoadGolden(path)
+	if err != nil {
+		t.Fatalf("LoadGolden() of a missing file error = %v", err)
+	}
+	golden.Set(1, 1, Int(55488))
+	golden.Set(1, 2, Int(55614))
+	golden.Set(3, 1, Int(529618))
+	if err := golden.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	golden, err = LoadGolden(path)
+	if err != nil {
+		t.Fatalf("LoadGolden() error = %v", err)
+	}
+	if got, ok := golden.Answer(1, 2); !ok || !got.Equal(Int(55614)) {
+		t.Errorf("Answer(1, 2) = %v, %v, want 55614, true", got, ok)
+	}
+	if got, ok := golden.Answer(3, 2); ok {
+		t.Errorf("Answer(3, 2) = %v, %v, want unknown", got, ok)
+	}
+	if got, ok := golden.Answer(9, 1); ok {
+		t.Errorf("Answer(9, 1) = %v, %v, want unknown", got, ok)
+	}
+}
@@ -0,0 +1,51 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Input picks the puzzle input for a day: a file path, "-" for stdin, or the
+// 1-based index of one of the day's examples. The zero Input is the day's
+// embedded input.txt.
+type Input struct {
+	Path    string
+	Example int
+}
+
+// Read returns the selected input with trailing newlines removed.
+func (in Input) Read(day Day, stdin io.Reader) (string, error) {
+	var input, source string
+	switch {
+	case in.Path != "" && in.Example != 0:
+		return "", errors.New("choose either an input file or an example, not both")
+	case in.Path == "-":
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("reading input from stdin: %w", err)
+		}
+		input, source = string(data), "stdin"
+	case in.Path != "":
+		data, err := os.ReadFile(in.Path)
+		if err != nil {
+			return "", fmt.Errorf("reading input: %w", err)
+		}
+		input, source = string(data), in.Path
+	case in.Example != 0:
+		if in.Example < 1 || in.Example > len(day.Examples) {
+			return "", fmt.Errorf("day %d has %d examples, no example %d", day.Number, len(day.Examples), in.Example)
+		}
+		input, source = day.Examples[in.Example-1].Input, fmt.Sprintf("example %d", in.Example)
+	default:
+		input, source = day.Input, "embedded input.txt"
+	}
+
+	input = strings.TrimRight(input, "\n")
+	if len(input) == 0 {
+		return "", fmt.Errorf("day %d: %s is empty", day.Number, source)
+	}
+	return input, nil
+}
@@ -0,0 +1,53 @@
+package aoc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInputRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("from file\n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	day := Day{
+		Number:   1,
+		Input:    "embedded\n",
+		Examples: []Example{{Input: "first example"}, {Input: "second example"}},
+	}
+
+	tests := []struct {
+		name    string
+		in      Input
+		want    string
+		wantErr bool
+	}{
+		{name: "embedded", in: Input{}, want: "embedded"},
+		{name: "file", in: Input{Path: path}, want: "from file"},
+		{name: "stdin", in: Input{Path: "-"}, want: "from stdin"},
+		{name: "example", in: Input{Example: 2}, want: "second example"},
+		{name: "missing example", in: Input{Example: 3}, wantErr: true},
+		{name: "missing file", in: Input{Path: path + ".missing"}, wantErr: true},
+		{name: "file and example", in: Input{Path: path, Example: 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.in.Read(day, strings.NewReader("from stdin\n"))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Read() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputReadEmpty(t *testing.T) {
+	if _, err := (Input{}).Read(Day{Number: 1, Input: "\n"}, nil); err == nil {
+		t.Errorf("Read() error = nil, want error for empty embedded input")
+	}
+}
@@ -0,0 +1,106 @@
+// Package ledger records every answer submitted for a puzzle, so that known
+// wrong answers are never submitted twice.
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/aoc/client"
+)
+
+type Attempt struct {
+	Day     int            `json:"day"`
+	Part    int            `json:"part"`
+	Answer  string         `json:"answer"`
+	Outcome client.Outcome `json:"outcome"`
+	Time    time.Time      `json:"time"`
+}
+
+// Ledger is the guess history of one user, stored as JSON at path.
+type Ledger struct {
+	path     string
+	Attempts []Attempt
+}
+
+// Open reads the ledger at path. A missing file is an empty ledger.
+func Open(path string) (*Ledger, error) {
+	l := &Ledger{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &l.Attempts); err != nil {
+		return nil, fmt.Errorf("reading ledger %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// Record appends an attempt and saves the ledger.
+func (l *Ledger) Record(attempt Attempt) error {
+	l.Attempts = append(l.Attempts, attempt)
+
+	data, err := json.MarshalIndent(l.Attempts, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(l.path, append(data, '\n'), 0o644)
+}
+
+// Solved returns the accepted answer for a part, if there is one.
+func (l *Ledger) Solved(day int, part int) (aoc.Answer, bool) {
+	for _, attempt := range l.Attempts {
+		if attempt.Day == day && attempt.Part == part && attempt.Outcome == client.Correct {
+			return aoc.ParseAnswer(attempt.Answer), true
+		}
+	}
+	return aoc.Answer{}, false
+}
+
+// Check returns an error explaining why an answer should not be submitted:
+// the part is already solved, the answer was already rejected, or it lies
+// outside the bounds set by earlier too high and too low verdicts.
+func (l *Ledger) Check(day int, part int, answer aoc.Answer) error {
+	if solved, ok := l.Solved(day, part); ok {
+		if solved.Equal(answer) {
+			return fmt.Errorf("day %d part %d is already solved with %v", day, part, solved)
+		}
+		return fmt.Errorf("day %d part %d is already solved with %v, not %v", day, part, solved, answer)
+	}
+
+	value, isInt := answer.BigInt()
+	for _, attempt := range l.Attempts {
+		if attempt.Day != day || attempt.Part != part || !attempt.Outcome.Wrong() {
+			continue
+		}
+
+		guess := aoc.ParseAnswer(attempt.Answer)
+		if guess.Equal(answer) {
+			return fmt.Errorf("%v was already rejected as %v on %s", answer, attempt.Outcome, attempt.Time.Format(time.DateTime))
+		}
+
+		bound, ok := guess.BigInt()
+		if !isInt || !ok {
+			continue
+		}
+		if attempt.Outcome == client.TooHigh && value.Cmp(bound) >= 0 {
+			return fmt.Errorf("%v is not below %v, which was too high", answer, guess)
+		}
+		if attempt.Outcome == client.TooLow && value.Cmp(bound) <= 0 {
+			return fmt.Errorf("%v is not above %v, which was too low", answer, guess)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package ledger
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/aoc/client"
+)
+
+func TestCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.json")
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attempts := []Attempt{
+		{Day: 5, Part: 1, Answer: "500", Outcome: client.TooHigh},
+		{Day: 5, Part: 1, Answer: "100", Outcome: client.TooLow},
+		{Day: 5, Part: 1, Answer: "300", Outcome: client.Incorrect},
+		{Day: 5, Part: 1, Answer: "250", Outcome: client.TooSoon},
+		{Day: 6, Part: 2, Answer: "42", Outcome: client.Correct},
+	}
+	for _, attempt := range attempts {
+		attempt.Time = time.Date(2023, 12, 5, 6, 0, 0, 0, time.UTC)
+		if err := l.Record(attempt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Reopen to check the ledger survives a round trip through the file.
+	l, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		day     int
+		part    int
+		answer  aoc.Answer
+		wantErr bool
+	}{
+		{name: "within bounds", day: 5, part: 1, answer: aoc.Int(200), wantErr: false},
+		{name: "not checked yet", day: 5, part: 1, answer: aoc.Int(250), wantErr: false},
+		{name: "known wrong", day: 5, part: 1, answer: aoc.Int(300), wantErr: true},
+		{name: "too high", day: 5, part: 1, answer: aoc.Int(501), wantErr: true},
+		{name: "equal to too high", day: 5, part: 1, answer: aoc.Int(500), wantErr: true},
+		{name: "too low", day: 5, part: 1, answer: aoc.Int(7), wantErr: true},
+		{name: "other part", day: 5, part: 2, answer: aoc.Int(7), wantErr: false},
+		{name: "already solved", day: 6, part: 2, answer: aoc.Int(42), wantErr: true},
+		{name: "text answer", day: 5, part: 1, answer: aoc.Text("abc"), wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := l.Check(tt.day, tt.part, tt.answer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check(%v) error = %v, wantErr %v", tt.answer, err, tt.wantErr)
+			}
+		})
+	}
+
+	if got, ok := l.Solved(6, 2); !ok || !got.Equal(aoc.Int(42)) {
+		t.Errorf("Solved(6, 2) = %v, %v, want 42, true", got, ok)
+	}
+}
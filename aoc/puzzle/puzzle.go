@@ -0,0 +1,56 @@
+// Package puzzle extracts the pieces of a puzzle description page that the
+// tooling needs, such as its examples and their expected answers.
+package puzzle
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+var (
+	articleRe   = regexp.MustCompile(`(?s)<article class="day-desc">(.*?)</article>`)
+	codeBlockRe = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	// The answer to an example is, by convention, the last emphasized code in
+	// the description of the part.
+	answerRe = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>`)
+	tagRe    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Examples returns the text of every <pre><code> block on the page, in order.
+func Examples(page string) []string {
+	examples := []string{}
+	for _, match := range codeBlockRe.FindAllStringSubmatch(page, -1) {
+		examples = append(examples, text(match[1]))
+	}
+	return examples
+}
+
+// Fixtures returns the example of each part described on the page with its
+// expected answer. A part without a code block of its own reuses the example
+// of the previous part, as part two usually does.
+func Fixtures(page string) []aoc.Example {
+	fixtures := []aoc.Example{}
+	for part, article := range articleRe.FindAllStringSubmatch(page, 2) {
+		answer := aoc.Answer{}
+		if answers := answerRe.FindAllStringSubmatch(article[1], -1); len(answers) > 0 {
+			answer = aoc.ParseAnswer(text(answers[len(answers)-1][1]))
+		}
+
+		if block := codeBlockRe.FindStringSubmatch(article[1]); block != nil {
+			fixtures = append(fixtures, aoc.Example{Input: text(block[1])})
+		} else if len(fixtures) == 0 {
+			continue
+		}
+		fixtures[len(fixtures)-1].Answers[part] = answer
+	}
+	return fixtures
+}
+
+// text strips the markup from an HTML fragment, such as the <em> used to
+// highlight parts of an example, and removes trailing newlines.
+func text(fragment string) string {
+	return strings.TrimRight(html.UnescapeString(tagRe.ReplaceAllString(fragment, "")), "\n")
+}
@@ -0,0 +1,76 @@
+package puzzle
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+func TestExamples(t *testing.T) {
+	page := `<article class="day-desc"><h2>--- Day 3: Gear Ratios ---</h2>
+<p>Here is an example engine schematic:</p>
+<pre><code>467..114..
+...*......
+..35..&lt;33.
+</code></pre>
+<p>In this schematic, two numbers are <em>not</em> part numbers.</p>
+<pre><code><em>4</em>67
+</code></pre>
+</article>`
+
+	want := []string{"467..114..\n...*......\n..35..<33.", "467"}
+	if got := Examples(page); !slices.Equal(got, want) {
+		t.Errorf("Examples() = %q, want %q", got, want)
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want []aoc.Example
+	}{
+		{
+			name: "shared example",
+			page: "testdata/day06.html",
+			want: []aoc.Example{
+				{Input: "Time:      7  15   30\nDistance:  9  40  200", Answers: [2]aoc.Answer{aoc.Int(288), aoc.Int(71503)}},
+			},
+		},
+		{
+			name: "example per part",
+			page: "testdata/day01.html",
+			want: []aoc.Example{
+				{Input: "1abc2\npqr3stu8vwx", Answers: [2]aoc.Answer{aoc.Int(142)}},
+				{Input: "two1nine\neightwothree", Answers: [2]aoc.Answer{{}, aoc.Int(281)}},
+			},
+		},
+		{
+			name: "part one only",
+			page: "testdata/day08.html",
+			want: []aoc.Example{
+				{Input: "LLR\n\nAAA = (BBB, BBB)", Answers: [2]aoc.Answer{aoc.Int(6)}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := os.ReadFile(tt.page)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := Fixtures(string(page))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Fixtures() = %v, want %v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				if got[i].Input != want.Input || !got[i].Answers[0].Equal(want.Answers[0]) || !got[i].Answers[1].Equal(want.Answers[1]) {
+					t.Errorf("Fixtures()[%d] = %v, want %v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
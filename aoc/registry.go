@@ -0,0 +1,51 @@
+// Package aoc is the solver registry shared by every day package and the aoc
+// command. Each day registers a Solver from init, so adding a day only means
+// adding a registration and an import in package days.
+package aoc
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+// Year is the Advent of Code event these solutions are for.
+const Year = 2023
+
+type Day struct {
+	Number int
+	// Input is the day's embedded input.txt.
+	Input string
+	// Examples are the day's testdata fixtures, selectable with Input.Example.
+	Examples []Example
+	New      func() Solver
+}
+
+var registry = make(map[int]Day)
+
+// Register adds a day to the registry. It panics if the day is already
+// registered.
+func Register(day Day) {
+	if _, ok := registry[day.Number]; ok {
+		panic(fmt.Sprintf("aoc: day %d registered twice", day.Number))
+	}
+	registry[day.Number] = day
+}
+
+func Lookup(number int) (Day, error) {
+	day, ok := registry[number]
+	if !ok {
+		return Day{}, fmt.Errorf("day %d is not registered", number)
+	}
+	return day, nil
+}
+
+// Days returns every registered day in ascending order.
+func Days() []Day {
+	days := lo.Values(registry)
+	slices.SortFunc(days, func(a Day, b Day) int {
+		return a.Number - b.Number
+	})
+	return days
+}
@@ -0,0 +1,73 @@
+package aoc
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	t.Cleanup(func() { registry = make(map[int]Day) })
+
+	Register(Day{
+		Number: 2,
+		New:    Funcs(func(input string) (int, error) { return len(input), nil }, func(input string) (int64, error) { return 2, nil }),
+	})
+	Register(Day{
+		Number: 1,
+		New:    Funcs(func(input string) (string, error) { return input, nil }, func(input string) (int, error) { return 1, nil }),
+	})
+
+	days := Days()
+	if len(days) != 2 || days[0].Number != 1 || days[1].Number != 2 {
+		t.Fatalf("Days() = %v, want days 1 and 2 in order", days)
+	}
+
+	day, err := Lookup(2)
+	if err != nil {
+		t.Fatalf("Lookup(2) error = %v", err)
+	}
+	if got, _ := day.Solve(1, "abc"); !got.Equal(Int(3)) {
+		t.Errorf("Solve(1) = %v, want 3", got)
+	}
+	if got, _ := day.Solve(2, "abc"); !got.Equal(Int(2)) {
+		t.Errorf("Solve(2) = %v, want 2", got)
+	}
+	if _, err := day.Solve(3, "abc"); err == nil {
+		t.Errorf("Solve(3) error = nil, want error")
+	}
+	if _, err := Lookup(3); err == nil {
+		t.Errorf("Lookup(3) error = nil, want error")
+	}
+}
+
+func TestSolveRecoversPanic(t *testing.T) {
+	day := Day{
+		Number: 1,
+		New:    Funcs(func(input string) (int, error) { panic("boom") }, func(input string) (int, error) { return 0, nil }),
+	}
+
+	if _, err := day.Solve(1, ""); err == nil {
+		t.Errorf("Solve() error = nil, want panic reported as error")
+	}
+}
+
+func TestSolveReturnsPartErrors(t *testing.T) {
+	day := Day{
+		Number: 1,
+		New: Funcs(
+			func(input string) (int, error) { return 0, errors.New("bad input") },
+			func(input string) (int, error) { return 0, nil },
+		),
+	}
+
+	if _, err := day.Solve(1, ""); err == nil || err.Error() != "bad input" {
+		t.Errorf("Solve() error = %v, want bad input", err)
+	}
+}
+
+func TestParsesInParts(t *testing.T) {
+	funcs := Day{Number: 1, New: Funcs(func(input string) (int, error) { return 0, nil }, func(input string) (int, error) { return 0, nil })}
+	if !funcs.ParsesInParts() {
+		t.Errorf("ParsesInParts() = false for a Funcs day")
+	}
+}
@@ -0,0 +1,106 @@
+// Package scaffold generates the package for a new day from templates that
+// follow the layout of the existing days.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+const Module = "github.com/basokant/advent-of-code-2023"
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+type Day struct {
+	Day int
+	// Input is written to input.txt and may be empty.
+	Input string
+	// Examples are written to testdata. An empty example1.txt is written when
+	// there are none, for the test skeleton to use once it is filled in.
+	Examples []aoc.Example
+}
+
+func (d Day) Package() string {
+	return fmt.Sprintf("day%02d", d.Day)
+}
+
+// Generate writes the new day's package into root/dayNN and registers it in
+// root/days/days.go. It refuses to overwrite an existing day.
+func Generate(root string, day Day) error {
+	dir := filepath.Join(root, day.Package())
+	if _, err := os.Stat(filepath.Join(dir, "main.go")); err == nil {
+		return fmt.Errorf("%s already exists", dir)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"main.go", "main_test.go", "examples.go"} {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, name+".tmpl", day); err != nil {
+			return err
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("formatting %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), src, 0o644); err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "input.txt"), []byte(day.Input), 0o644); err != nil {
+		return err
+	}
+
+	examples := day.Examples
+	if len(examples) == 0 {
+		examples = []aoc.Example{{}}
+	}
+	for i, example := range examples {
+		if err := aoc.WriteExample(dir, i+1, example); err != nil {
+			return err
+		}
+	}
+
+	return AddImport(filepath.Join(root, "days", "days.go"), Module+"/"+day.Package())
+}
+
+// AddImport adds a blank import of path to the Go file at filename, keeping
+// the import block sorted.
+func AddImport(filename string, path string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	line := fmt.Sprintf("\t_ %q\n", path)
+	src := string(data)
+	if strings.Contains(src, line) {
+		return nil
+	}
+
+	end := strings.Index(src, "\n)\n")
+	if end == -1 {
+		return fmt.Errorf("%s has no import block", filename)
+	}
+	src = src[:end+1] + line + src[end+1:]
+
+	// format.Source sorts the imports within the block.
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return fmt.Errorf("formatting %s: %w", filename, err)
+	}
+	return os.WriteFile(filename, formatted, 0o644)
+}
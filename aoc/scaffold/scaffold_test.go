@@ -0,0 +1,55 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+func TestGenerate(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "days"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	days := "package days\n\nimport (\n\t_ \"" + Module + "/day01\"\n\t_ \"" + Module + "/day11\"\n)\n"
+	if err := os.WriteFile(filepath.Join(root, "days", "days.go"), []byte(days), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	day := Day{
+		Day:      10,
+		Input:    "..F7.\n",
+		Examples: []aoc.Example{{Input: "-L|F7\n7S-7|", Answers: [2]aoc.Answer{aoc.Int(4)}}},
+	}
+	if err := Generate(root, day); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	examples, err := aoc.LoadExamples(os.DirFS(filepath.Join(root, "day10")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(examples) != 1 || examples[0].Input != day.Examples[0].Input || !examples[0].Answers[0].Equal(aoc.Int(4)) {
+		t.Errorf("testdata holds %v, want %v", examples, day.Examples)
+	}
+
+	input, err := os.ReadFile(filepath.Join(root, "day10", "input.txt"))
+	if err != nil || string(input) != day.Input {
+		t.Errorf("input.txt = %q, %v, want %q", input, err, day.Input)
+	}
+
+	got, err := os.ReadFile(filepath.Join(root, "days", "days.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package days\n\nimport (\n\t_ \"" + Module + "/day01\"\n\t_ \"" + Module + "/day10\"\n\t_ \"" + Module + "/day11\"\n)\n"
+	if string(got) != want {
+		t.Errorf("days.go =\n%s\nwant\n%s", got, want)
+	}
+
+	if err := Generate(root, day); err == nil {
+		t.Errorf("Generate() error = nil, want error for an existing day")
+	}
+}
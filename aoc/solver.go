@@ -0,0 +1,85 @@
+package aoc
+
+import (
+	"fmt"
+)
+
+// Solver solves both parts of a day. Parse is always called before Part1 or
+// Part2, so solvers may keep the parsed input between the calls.
+type Solver interface {
+	Parse(input string) error
+	Part1() (Answer, error)
+	Part2() (Answer, error)
+}
+
+// Solve runs a fresh solver for the given part of the day against input. A
+// panicking solver is reported as an error so one broken day cannot take down
+// a run over every day.
+func (d Day) Solve(part int, input string) (ans Answer, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			ans, err = Answer{}, fmt.Errorf("day %d part %d panicked: %v", d.Number, part, r)
+		}
+	}()
+
+	if part != 1 && part != 2 {
+		return Answer{}, fmt.Errorf("day %d has no part %d", d.Number, part)
+	}
+
+	solver := d.New()
+	if err := solver.Parse(input); err != nil {
+		return Answer{}, fmt.Errorf("day %d: parsing input: %w", d.Number, err)
+	}
+
+	if part == 1 {
+		return solver.Part1()
+	}
+	return solver.Part2()
+}
+
+// ParsesInParts reports whether the day's Parse does no work, leaving each
+// part to parse the raw input itself, as for days registered with Funcs.
+func (d Day) ParsesInParts() bool {
+	_, ok := d.New().(*funcSolver)
+	return ok
+}
+
+// Funcs adapts part functions that each take the raw input into a Solver.
+// Errors returned by a part function are returned by Solve.
+func Funcs[A, B Value](part1 func(string) (A, error), part2 func(string) (B, error)) func() Solver {
+	return func() Solver {
+		return &funcSolver{
+			part1: answerFunc(part1),
+			part2: answerFunc(part2),
+		}
+	}
+}
+
+func answerFunc[T Value](part func(string) (T, error)) func(string) (Answer, error) {
+	return func(input string) (Answer, error) {
+		v, err := part(input)
+		if err != nil {
+			return Answer{}, err
+		}
+		return NewAnswer(v), nil
+	}
+}
+
+type funcSolver struct {
+	input string
+	part1 func(string) (Answer, error)
+	part2 func(string) (Answer, error)
+}
+
+func (s *funcSolver) Parse(input string) error {
+	s.input = input
+	return nil
+}
+
+func (s *funcSolver) Part1() (Answer, error) {
+	return s.part1(s.input)
+}
+
+func (s *funcSolver) Part2() (Answer, error) {
+	return s.part2(s.input)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/aoc/bench"
+)
+
+func benchCommand(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	var day, part, iterations int
+	var baselinePath, savePath string
+	var threshold float64
+	fs.IntVar(&day, "day", 0, "day to benchmark (default: every day)")
+	fs.IntVar(&part, "part", 0, "only benchmark parsing and this part (default: both parts)")
+	fs.IntVar(&iterations, "n", 10, "iterations per stage")
+	fs.StringVar(&baselinePath, "baseline", "", "compare against results saved with -save")
+	fs.StringVar(&savePath, "save", "", "save the results as a baseline")
+	fs.Float64Var(&threshold, "threshold", 1.2, "flag stages slower than the baseline by more than this factor")
+	fs.Parse(args)
+
+	if iterations < 1 {
+		return errors.New("-n must be at least 1")
+	}
+	if part != 0 && part != 1 && part != 2 {
+		return fmt.Errorf("-part must be 1 or 2, got %d", part)
+	}
+
+	stages := bench.Stages
+	if part != 0 {
+		stages = []string{"parse", fmt.Sprintf("part%d", part)}
+	}
+
+	days := aoc.Days()
+	if day != 0 {
+		d, err := aoc.Lookup(day)
+		if err != nil {
+			return err
+		}
+		days = []aoc.Day{d}
+	}
+
+	results := []bench.Result{}
+	failed := 0
+	for _, d := range days {
+		input, err := aoc.Input{}.Read(d, nil)
+		if err != nil {
+			return err
+		}
+		dayResults, err := bench.Run(d, input, stages, iterations)
+		results = append(results, dayResults...)
+		if err != nil {
+			// Run joins the error of each failed stage with newlines.
+			for _, line := range strings.Split(err.Error(), "\n") {
+				fmt.Fprintln(os.Stderr, "aoc:", line)
+				failed += 1
+			}
+		}
+	}
+
+	var baseline []bench.Result
+	if baselinePath != "" {
+		var err error
+		if baseline, err = bench.Load(baselinePath); err != nil {
+			return err
+		}
+	}
+
+	comparisons := bench.Compare(results, baseline, threshold)
+	if err := bench.WriteTable(os.Stdout, comparisons); err != nil {
+		return err
+	}
+
+	if savePath != "" {
+		if err := bench.Save(savePath, results); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d stages failed", failed)
+	}
+
+	slower := 0
+	for _, c := range comparisons {
+		if c.Slower {
+			slower += 1
+		}
+	}
+	if slower > 0 {
+		return fmt.Errorf("%d stages are slower than the baseline", slower)
+	}
+	return nil
+}
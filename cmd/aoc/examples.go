@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/aoc/puzzle"
+)
+
+func examplesCommand(args []string) error {
+	fs := flag.NewFlagSet("examples", flag.ExitOnError)
+	var day int
+	var page, root string
+	fs.IntVar(&day, "day", 0, "day to extract the examples for")
+	fs.StringVar(&page, "page", "", "saved puzzle page (default: download it)")
+	fs.StringVar(&root, "root", ".", "repository root")
+	fs.Parse(args)
+
+	if day < 1 || day > 25 {
+		return fmt.Errorf("-day must be between 1 and 25")
+	}
+
+	var html string
+	if page != "" {
+		data, err := os.ReadFile(page)
+		if err != nil {
+			return err
+		}
+		html = string(data)
+	} else {
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		if html, err = c.Puzzle(context.Background(), aoc.Year, day); err != nil {
+			return err
+		}
+	}
+
+	fixtures := puzzle.Fixtures(html)
+	if len(fixtures) == 0 {
+		return fmt.Errorf("no examples found on the puzzle page")
+	}
+
+	dir := filepath.Join(root, fmt.Sprintf("day%02d", day))
+	examples, err := aoc.LoadExamples(os.DirFS(dir))
+	if err != nil {
+		return err
+	}
+
+	for _, fixture := range fixtures {
+		n := mergeExample(&examples, fixture)
+		if err := aoc.WriteExample(dir, n, examples[n-1]); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s example %d\n", filepath.Base(dir), n)
+	}
+	return nil
+}
+
+// mergeExample adds the fixture's answers to the existing example with the
+// same input, or appends it as a new example, and returns its number.
+func mergeExample(examples *[]aoc.Example, fixture aoc.Example) int {
+	for i, example := range *examples {
+		if example.Input != fixture.Input {
+			continue
+		}
+		for part, answer := range fixture.Answers {
+			if !answer.IsZero() {
+				(*examples)[i].Answers[part] = answer
+			}
+		}
+		return i + 1
+	}
+
+	*examples = append(*examples, fixture)
+	return len(*examples)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/aoc/client"
+)
+
+func fetchCommand(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	var day int
+	var out string
+	fs.IntVar(&day, "day", 0, "day to download the input for")
+	fs.StringVar(&out, "out", "", "where to write the input (default: dayNN/input.txt)")
+	fs.Parse(args)
+
+	if day < 1 || day > 25 {
+		return fmt.Errorf("-day must be between 1 and 25")
+	}
+	if out == "" {
+		out = filepath.Join(fmt.Sprintf("day%02d", day), "input.txt")
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	input, err := c.Input(context.Background(), aoc.Year, day)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, []byte(input), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Println("Wrote", out)
+	return nil
+}
+
+func newClient() (*client.Client, error) {
+	session, err := client.Session()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(session)
+}
@@ -0,0 +1,64 @@
+// Command aoc runs the registered Advent of Code solvers.
+//
+// Usage:
+//
+//	aoc run -day 7 -part 2
+//	aoc run -day 7 -part 2 -explain table
+//	aoc run -all
+//	aoc fetch -day 10
+//	aoc submit -day 10 -part 1
+//	aoc new -day 11 -fetch
+//	aoc examples -day 11 -page day11.html
+//	aoc bench -day 6 -baseline bench.json
+package main
+
+import (
+	"fmt"
+	"os"
+
+	_ "github.com/basokant/advent-of-code-2023/days"
+)
+
+const usage = `usage: aoc <command> [flags]
+
+commands:
+  run       run the solver for a day, or every day with -all
+  fetch     download a day's puzzle input to dayNN/input.txt
+  submit    submit a day's answer, refusing answers known to be wrong
+  new       create the package for a new day from a template
+  examples  extract a puzzle page's examples into dayNN/testdata
+  bench     time parsing and each part, optionally against a baseline
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "run":
+		err = runCommand(args)
+	case "fetch":
+		err = fetchCommand(args)
+	case "submit":
+		err = submitCommand(args)
+	case "new":
+		err = newCommand(args)
+	case "examples":
+		err = examplesCommand(args)
+	case "bench":
+		err = benchCommand(args)
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "aoc:", err)
+		os.Exit(1)
+	}
+}
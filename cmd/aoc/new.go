@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/aoc/puzzle"
+	"github.com/basokant/advent-of-code-2023/aoc/scaffold"
+)
+
+func newCommand(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ExitOnError)
+	var day int
+	var fetch bool
+	var root string
+	fs.IntVar(&day, "day", 0, "day to create")
+	fs.BoolVar(&fetch, "fetch", false, "download the input and the examples from the puzzle page")
+	fs.StringVar(&root, "root", ".", "repository root")
+	fs.Parse(args)
+
+	if day < 1 || day > 25 {
+		return fmt.Errorf("-day must be between 1 and 25")
+	}
+
+	d := scaffold.Day{Day: day}
+	if fetch {
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		if d.Input, err = c.Input(ctx, aoc.Year, day); err != nil {
+			return err
+		}
+
+		page, err := c.Puzzle(ctx, aoc.Year, day)
+		if err != nil {
+			return err
+		}
+		d.Examples = puzzle.Fixtures(page)
+	}
+
+	if err := scaffold.Generate(root, d); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s\n", d.Package())
+	return nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/util"
+)
+
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	var day, part int
+	var all bool
+	var clipboard, explain string
+	var in aoc.Input
+	fs.IntVar(&day, "day", 0, "day to run")
+	fs.IntVar(&part, "part", 1, "part 1 or 2")
+	fs.BoolVar(&all, "all", false, "run both parts of every registered day")
+	fs.StringVar(&in.Path, "input", "", "input file, or - for stdin (default: embedded input.txt)")
+	fs.IntVar(&in.Example, "example", 0, "run against the day's Nth example input instead")
+	fs.StringVar(&clipboard, "clipboard", "", "clipboard backend: pbcopy, wl-copy, xclip, xsel, osc52, file:PATH or none (default: detect)")
+	fs.StringVar(&explain, "explain", "", "also explain the answer as a table or json, if the day can")
+	fs.Parse(args)
+
+	if all {
+		if explain != "" {
+			return fmt.Errorf("-explain cannot be used with -all")
+		}
+		return runAll(in, clipboard)
+	}
+
+	var format aoc.ExplainFormat
+	if explain != "" {
+		var err error
+		if format, err = aoc.ParseExplainFormat(explain); err != nil {
+			return err
+		}
+	}
+
+	// The explanation may be JSON for another program to read, so keep
+	// stdout for it alone.
+	status := io.Writer(os.Stdout)
+	if explain != "" {
+		status = os.Stderr
+	}
+
+	fmt.Fprintln(status, "Running day", day, "part", part)
+	d, input, err := load(day, in)
+	if err != nil {
+		return err
+	}
+	ans, err := d.Solve(part, input)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(status, "Output:", ans)
+	copyText(clipboard, ans.String())
+
+	if explain != "" {
+		return d.Explain(os.Stdout, part, input, format)
+	}
+	return nil
+}
+
+// load looks up the day and reads its input, which is read only once since it
+// may come from stdin.
+func load(day int, in aoc.Input) (aoc.Day, string, error) {
+	d, err := aoc.Lookup(day)
+	if err != nil {
+		return aoc.Day{}, "", err
+	}
+
+	input, err := in.Read(d, os.Stdin)
+	if err != nil {
+		return aoc.Day{}, "", err
+	}
+	return d, input, nil
+}
+
+func solve(day int, part int, in aoc.Input) (aoc.Answer, error) {
+	d, input, err := load(day, in)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return d.Solve(part, input)
+}
+
+// copyText copies answers to the clipboard. Failing to do so is only worth a
+// warning since the answers have already been printed.
+func copyText(backend string, text string) {
+	clipboard, err := util.NewClipboard(backend)
+	if err == nil {
+		err = clipboard.Copy(text)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "aoc: answer not copied:", err)
+	}
+}
+
+// runAll solves every part of every day, and copies the summary of answers
+// with the chosen clipboard backend.
+func runAll(in aoc.Input, clipboard string) error {
+	if in.Path != "" {
+		return fmt.Errorf("-input cannot be used with -all")
+	}
+
+	var summary strings.Builder
+	out := io.MultiWriter(os.Stdout, &summary)
+
+	failed := 0
+	for _, d := range aoc.Days() {
+		input, err := in.Read(d, nil)
+		if err != nil {
+			failed += 1
+			fmt.Fprintf(out, "day %02d: error: %v\n", d.Number, err)
+			continue
+		}
+
+		for part := 1; part <= 2; part++ {
+			ans, err := d.Solve(part, input)
+			if err != nil {
+				failed += 1
+				fmt.Fprintf(out, "day %02d part %d: error: %v\n", d.Number, part, err)
+				continue
+			}
+			fmt.Fprintf(out, "day %02d part %d: %v\n", d.Number, part, ans)
+		}
+	}
+	copyText(clipboard, summary.String())
+
+	if failed > 0 {
+		return fmt.Errorf("%d parts failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/aoc/client"
+	"github.com/basokant/advent-of-code-2023/aoc/ledger"
+)
+
+func submitCommand(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	var day, part int
+	var in aoc.Input
+	var answers string
+	fs.IntVar(&day, "day", 0, "day to submit")
+	fs.IntVar(&part, "part", 1, "part 1 or 2")
+	fs.StringVar(&in.Path, "input", "", "input file, or - for stdin (default: embedded input.txt)")
+	fs.StringVar(&answers, "answers", "answers.json", "golden answers file to record accepted answers in")
+	fs.Parse(args)
+
+	ans, err := solve(day, part, in)
+	if err != nil {
+		return err
+	}
+	if ans.IsZero() {
+		return fmt.Errorf("day %d part %d produced no answer, not submitting", day, part)
+	}
+	fmt.Println("Answer:", ans)
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	path := c.UserPath(aoc.Year, "ledger.json")
+	if path == "" {
+		return errors.New("no state directory to keep the ledger of submissions in")
+	}
+	l, err := ledger.Open(path)
+	if err != nil {
+		return err
+	}
+	if err := l.Check(day, part, ans); err != nil {
+		return fmt.Errorf("not submitting: %w", err)
+	}
+
+	result, err := c.Submit(context.Background(), aoc.Year, day, part, ans.String())
+	if err != nil {
+		return err
+	}
+
+	err = l.Record(ledger.Attempt{
+		Day:     day,
+		Part:    part,
+		Answer:  ans.String(),
+		Outcome: result.Outcome,
+		Time:    time.Now(),
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "aoc: attempt not recorded:", err)
+	}
+
+	fmt.Println("Result:", result.Outcome)
+	if result.Wait > 0 {
+		fmt.Println("Wait", result.Wait, "before the next attempt")
+	}
+
+	switch result.Outcome {
+	case client.Correct:
+		return recordGolden(answers, day, part, ans)
+	case client.Unknown:
+		return errors.New("unrecognised response: " + result.Message)
+	}
+	return errors.New(result.Message)
+}
+
+func recordGolden(path string, day int, part int, ans aoc.Answer) error {
+	golden, err := aoc.LoadGolden(path)
+	if err != nil {
+		return err
+	}
+	golden.Set(day, part, ans)
+	return golden.Save(path)
+}
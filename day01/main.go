@@ -1,71 +1,61 @@
-package main
+package day01
 
 import (
 	_ "embed"
-	"flag"
 	"fmt"
 	"strconv"
 	"strings"
 	"unicode"
 
-	"github.com/basokant/advent-of-code-2023/util"
+	"github.com/basokant/advent-of-code-2023/aoc"
 )
 
 //go:embed input.txt
 var input string
 
 func init() {
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	aoc.Register(aoc.Day{
+		Number:   1,
+		Input:    input,
+		Examples: examples,
+		New:      aoc.Funcs(part1, part2),
+	})
 }
 
-func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
+func part1(input string) (int, error) {
+	calibrationValues, err := parseInput(input, false)
+	if err != nil {
+		return 0, err
 	}
-}
-
-func part1(input string) int {
-	calibrationValues := parseInput(input, false)
 
 	var sum int
 	for _, value := range calibrationValues {
 		sum += value
 	}
-	return sum
+	return sum, nil
 }
 
-func part2(input string) int {
-	calibrationValues := parseInput(input, true)
+func part2(input string) (int, error) {
+	calibrationValues, err := parseInput(input, true)
+	if err != nil {
+		return 0, err
+	}
 
 	var sum int
 	for _, value := range calibrationValues {
 		sum += value
 	}
-	return sum
+	return sum, nil
 }
 
-func parseInput(input string, shouldReplaceDigitWords bool) []int {
+func parseInput(input string, shouldReplaceDigitWords bool) ([]int, error) {
 	calibrationValues := []int{}
 
 	if shouldReplaceDigitWords {
 		input = replaceDigitWords(input)
 	}
 
-	for _, line := range strings.Split(input, "\n") {
+	for lineNum, line := range strings.Split(input, "\n") {
 		digits := []int{}
 		chars := []rune(line)
 
@@ -78,10 +68,13 @@ func parseInput(input string, shouldReplaceDigitWords bool) []int {
 			}
 		}
 
+		if len(digits) == 0 {
+			return nil, fmt.Errorf("line %d: no digits in %q", lineNum+1, line)
+		}
 		calibrationValue := digits[0]*10 + digits[len(digits)-1]
 		calibrationValues = append(calibrationValues, calibrationValue)
 	}
-	return calibrationValues
+	return calibrationValues, nil
 }
 
 func replaceDigitWords(input string) string {
@@ -1,47 +1,15 @@
-package main
+package day01
 
 import (
 	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc/aoctest"
 )
 
 func TestPart1(t *testing.T) {
-	tests := []struct {
-		name  string
-		input string
-		want  int
-	}{
-		{
-			name:  "example",
-			input: "1abc2\npqr3stu8vwx\na1b2c3d4e5f\ntreb7uchet",
-			want:  142,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := part1(tt.input); got != tt.want {
-				t.Errorf("part1() = %v, want %v", got, tt.want)
-			}
-		})
-	}
+	aoctest.Examples(t, 1, 1)
 }
 
 func TestPart2(t *testing.T) {
-	tests := []struct {
-		name  string
-		input string
-		want  int
-	}{
-		{
-			name:  "example",
-			input: "two1nine\neightwothree\nabcone2threexyz\nxtwone3four\n4nineeightseven2\nzoneight234\n7pqrstsixteen",
-			want:  281,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := part2(tt.input); got != tt.want {
-				t.Errorf("part2() = %v, want %v", got, tt.want)
-			}
-		})
-	}
+	aoctest.Examples(t, 1, 2)
 }
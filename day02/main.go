@@ -1,13 +1,12 @@
-package main
+package day02
 
 import (
 	_ "embed"
-	"flag"
 	"fmt"
 	"strconv"
 	"strings"
 
-	"github.com/basokant/advent-of-code-2023/util"
+	"github.com/basokant/advent-of-code-2023/aoc"
 )
 
 type Colour int
@@ -39,7 +38,7 @@ func StringToColour(s string) (Colour, error) {
 	case "green":
 		return Green, nil
 	}
-	return -1, nil
+	return -1, fmt.Errorf("unknown colour %q", s)
 }
 
 type Game struct {
@@ -78,37 +77,25 @@ func (g Game) getMinCubeCounts() map[Colour]int {
 var input string
 
 func init() {
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
+	aoc.Register(aoc.Day{
+		Number:   2,
+		Input:    input,
+		Examples: examples,
+		New:      aoc.Funcs(part1, part2),
+	})
 }
 
-func part1(input string) int {
+func part1(input string) (int, error) {
 	cubeCounts := map[Colour]int{
 		Red:   12,
 		Green: 13,
 		Blue:  14,
 	}
 
-	games := parseInput(input)
+	games, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
 	possibleGameIds := make([]int, len(games))
 	for i, game := range games {
@@ -122,11 +109,14 @@ func part1(input string) int {
 		sum += gameId
 	}
 
-	return sum
+	return sum, nil
 }
 
-func part2(input string) int {
-	games := parseInput(input)
+func part2(input string) (int, error) {
+	games, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
 	powers := make([]int, len(games))
 	for i, game := range games {
@@ -145,43 +135,54 @@ func part2(input string) int {
 		sum += power
 	}
 
-	return sum
+	return sum, nil
 }
 
-func parseInput(input string) []Game {
+func parseInput(input string) ([]Game, error) {
 	games := []Game{}
-	for _, line := range strings.Split(input, "\n") {
-		game := Game{}
 
-		tokens := strings.Split(line, ": ")
-		gameInfo, gameSets := tokens[0], tokens[1]
-		gameId, err := strconv.Atoi(strings.Split(gameInfo, " ")[1])
+	for i, line := range strings.Split(input, "\n") {
+		game := Game{}
+		gameInfo, gameSets, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, fmt.Errorf("line %d: want \"Game N: ...\", got %q", i+1, line)
+		}
 
-		if err == nil {
-			game.id = gameId
+		gameId, err := strconv.Atoi(strings.TrimPrefix(gameInfo, "Game "))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid game %q", i+1, gameInfo)
 		}
+		game.id = gameId
 
-		game.cubeSets = createCubeSets(gameSets)
+		game.cubeSets, err = createCubeSets(gameSets)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
 		games = append(games, game)
 	}
 
-	return games
+	return games, nil
 }
 
-func createCubeSets(gameSetsStr string) []map[Colour]int {
+func createCubeSets(gameSetsStr string) ([]map[Colour]int, error) {
 	cubeSets := []map[Colour]int{}
+
 	for _, set := range strings.Split(gameSetsStr, "; ") {
 		cubeSet := map[Colour]int{}
 		for _, subSet := range strings.Split(set, ", ") {
-			tokens := strings.Split(subSet, " ")
-			countStr, colourStr := tokens[0], tokens[1]
-
-			count, _ := strconv.Atoi(countStr)
-			colour, _ := StringToColour(colourStr)
+			countStr, colourStr, _ := strings.Cut(subSet, " ")
+			count, err := strconv.Atoi(countStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cube count %q", subSet)
+			}
+			colour, err := StringToColour(colourStr)
+			if err != nil {
+				return nil, err
+			}
 			cubeSet[colour] = count
 		}
 		cubeSets = append(cubeSets, cubeSet)
 	}
 
-	return cubeSets
+	return cubeSets, nil
 }
@@ -1,222 +1,149 @@
-package main
+package day03
 
 import (
 	_ "embed"
-	"flag"
-	"fmt"
-	"regexp"
-	"slices"
 	"strconv"
-	"strings"
 	"unicode"
 
-	"github.com/basokant/advent-of-code-2023/util"
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/util/grid"
 )
 
 //go:embed input.txt
 var input string
 
 func init() {
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	aoc.Register(aoc.Day{
+		Number:   3,
+		Input:    input,
+		Examples: examples,
+		New:      aoc.Funcs(part1, part2),
+	})
 }
 
-func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
+func part1(input string) (int, error) {
+	schematic, err := parseInput(input)
+	if err != nil {
+		return 0, err
 	}
-}
-
-func part1(input string) int {
-	partNums := findPartNumbers(input)
+	partNums := schematic.partNumbers()
 
 	sum := 0
 	for _, partNum := range partNums {
 		sum += partNum
 	}
 
-	return sum
+	return sum, nil
 }
 
-// TODO: solve day03 part2, currently not passing test.
-func part2(input string) int {
-	gearRatios := findGearRatios(input)
+func part2(input string) (int, error) {
+	schematic, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+	gearRatios := findGearRatios(schematic)
 
 	sum := 0
 	for _, gearRatio := range gearRatios {
 		sum += gearRatio
 	}
-	return sum
+	return sum, nil
 }
 
-func isAdjacentToSymbol(grid [][]rune, rowNum int, colNums []int) bool {
-	directions := [][]int{
-		{-1, -1},
-		{0, -1},
-		{1, -1},
-		{1, 0},
-		{1, 1},
-		{0, 1},
-		{-1, 1},
-		{-1, 0},
-	}
-
-	for _, colNum := range colNums {
-		for _, direction := range directions {
-			dx, dy := direction[0], direction[1]
-
-			isValidAdjacent := 0 <= rowNum+dy && rowNum+dy < len(grid) && 0 <= colNum+dx && colNum+dx < len(grid[rowNum])
-			if !isValidAdjacent {
-				continue
-			}
+func isSymbol(char rune) bool {
+	return !unicode.IsDigit(char) && char != '.'
+}
 
-			adjacentChar := grid[rowNum+dy][colNum+dx]
-			if !unicode.IsDigit(adjacentChar) && adjacentChar != '.' {
-				return true
-			}
-		}
-	}
+// Part is a number drawn in the schematic, which is a part number if it is
+// adjacent to a symbol.
+type Part struct {
+	span   grid.Span
+	number int
+}
 
-	return false
+// Schematic indexes which numbers touch which symbols, so that questions
+// about adjacency become lookups rather than scans of the grid.
+type Schematic struct {
+	grid  grid.Grid[rune]
+	parts []Part
+	// symbols maps each symbol's position to the indices of the parts
+	// adjacent to it, in the order the parts appear in the schematic.
+	symbols map[grid.Point][]int
 }
 
-func getGearRatio(grid [][]rune, potentialParts []Part, rowNum int, colNum int) int {
-	directions := [][]int{
-		{-1, -1},
-		{0, -1},
-		{1, -1},
-		{1, 0},
-		{1, 1},
-		{0, 1},
-		{-1, 1},
-		{-1, 0},
+func newSchematic(g grid.Grid[rune]) Schematic {
+	s := Schematic{grid: g, symbols: map[grid.Point][]int{}}
+	for _, p := range g.Points() {
+		if isSymbol(g.At(p)) {
+			s.symbols[p] = []int{}
+		}
 	}
 
-	parts := make([]Part, len(potentialParts))
-	copy(parts, potentialParts)
-
-	adjacentParts := []Part{}
-	for _, direction := range directions {
-		dx, dy := direction[0], direction[1]
+	for i, span := range g.Runs(unicode.IsDigit) {
+		num, _ := strconv.Atoi(string(g.Cells(span.Points())))
+		s.parts = append(s.parts, Part{span, num})
 
-		isValidAdjacent := 0 <= rowNum+dy && rowNum+dy < len(grid) && 0 <= colNum+dx && colNum+dx < len(grid[rowNum])
-		if !isValidAdjacent {
-			continue
-		}
-
-		adjacentChar := grid[rowNum+dy][colNum+dx]
-		if unicode.IsDigit(adjacentChar) {
-			for i, p := range parts {
-				isAdjacentPart := p.rowNum == rowNum+dy && colNum+dx >= p.colNums[0] && colNum+dx <= p.colNums[len(p.colNums)-1]
-				if !isAdjacentPart {
-					continue
-				}
-				adjacentParts = append(adjacentParts, p)
-				parts = slices.Delete(parts, i, i+1)
-
-				if len(adjacentParts) >= 2 {
-					return 0
-				}
+		for _, p := range g.Border(span.Points(), grid.Directions8) {
+			if adjacent, ok := s.symbols[p]; ok {
+				s.symbols[p] = append(adjacent, i)
 			}
 		}
 	}
-
-	if len(adjacentParts) == 2 {
-		return adjacentParts[0].number * adjacentParts[1].number
-	}
-
-	return 0
-}
-
-type Part struct {
-	colNums []int
-	number  int
-	rowNum  int
+	return s
 }
 
-func getPotentialParts(lines []string) []Part {
-	potentialParts := []Part{}
-	re := regexp.MustCompile("[0-9]+")
-
-	for rowNum, line := range lines {
-		nums := re.FindAllString(line, -1)
-		numCols := re.FindAllStringIndex(line, -1)
-
-		for i, numStr := range nums {
-			numCols[i][1] -= 1
-			num, _ := strconv.Atoi(numStr)
-			potentialPart := Part{
-				number:  num,
-				rowNum:  rowNum,
-				colNums: numCols[i],
-			}
-			potentialParts = append(potentialParts, potentialPart)
+// partNumbers returns the numbers adjacent to at least one symbol. A number
+// touching several symbols is only counted once.
+func (s Schematic) partNumbers() []int {
+	isPart := make([]bool, len(s.parts))
+	for _, adjacent := range s.symbols {
+		for _, i := range adjacent {
+			isPart[i] = true
 		}
 	}
 
-	return potentialParts
-}
-
-func findPartNumbers(input string) []int {
-	lines := strings.Split(input, "\n")
-	grid := parseInput(input)
-
 	partNumbers := []int{}
-	potentialParts := getPotentialParts(lines)
-
-	for _, potentialPart := range potentialParts {
-		if isAdjacentToSymbol(grid, potentialPart.rowNum, potentialPart.colNums) {
-			partNumbers = append(partNumbers, potentialPart.number)
+	for i, part := range s.parts {
+		if isPart[i] {
+			partNumbers = append(partNumbers, part.number)
 		}
 	}
-
 	return partNumbers
 }
 
-func findGearRatios(input string) []int {
-	lines := strings.Split(input, "\n")
-	grid := parseInput(input)
-
-	gearRatios := []int{}
-	potentialParts := getPotentialParts(lines)
-	re := regexp.MustCompile("[*]")
-
-	for rowNum := range grid {
-		gearIdxs := re.FindAllStringIndex(lines[rowNum], -1)
+// withExactly returns, for every occurrence of symbol adjacent to exactly n
+// numbers, those numbers. Occurrences are in row-major order.
+func (s Schematic) withExactly(symbol rune, n int) [][]int {
+	matches := [][]int{}
+	for _, p := range s.grid.Points() {
+		adjacent, ok := s.symbols[p]
+		if !ok || s.grid.At(p) != symbol || len(adjacent) != n {
+			continue
+		}
 
-		for _, colNums := range gearIdxs {
-			gearRatio := getGearRatio(grid, potentialParts, rowNum, colNums[0])
-			gearRatios = append(gearRatios, gearRatio)
+		numbers := make([]int, n)
+		for j, i := range adjacent {
+			numbers[j] = s.parts[i].number
 		}
+		matches = append(matches, numbers)
 	}
+	return matches
+}
 
+// findGearRatios returns the ratio of every gear: a '*' adjacent to exactly
+// two part numbers.
+func findGearRatios(schematic Schematic) []int {
+	gearRatios := []int{}
+	for _, numbers := range schematic.withExactly('*', 2) {
+		gearRatios = append(gearRatios, numbers[0]*numbers[1])
+	}
 	return gearRatios
 }
 
-func parseInput(input string) [][]rune {
-	lines := strings.Split(input, "\n")
-
-	grid := make([][]rune, len(lines))
-	for i, line := range lines {
-		row := make([]rune, len(line))
-		for j, char := range line {
-			row[j] = char
-		}
-		grid[i] = row
+func parseInput(input string) (Schematic, error) {
+	g, err := grid.Parse(input)
+	if err != nil {
+		return Schematic{}, err
 	}
-
-	return grid
+	return newSchematic(g), nil
 }
@@ -1,65 +1,64 @@
-package main
+package day03
 
 import (
 	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc/aoctest"
 )
 
 func TestPart1(t *testing.T) {
+	aoctest.Examples(t, 3, 1)
+}
+
+func TestPart2(t *testing.T) {
+	aoctest.Examples(t, 3, 2)
+}
+
+func TestGears(t *testing.T) {
 	tests := []struct {
 		name  string
 		input string
 		want  int
 	}{
 		{
-			name: "example",
-			input: `467..114..
-...*......
-..35..633.
-......#...
-617*......
-.....+.58.
-..592.....
-......755.
-...$.*....
-.664.598..`,
-			want: 4361,
+			name:  "three parts is not a gear",
+			input: "1.2\n.*.\n3..",
+			want:  0,
+		},
+		{
+			name:  "number touching gear twice",
+			input: "12\n*.\n3.",
+			want:  36,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := part1(tt.input); got != tt.want {
-				t.Errorf("part1() = %v, want %v", got, tt.want)
+			if got, err := part2(tt.input); err != nil || got != tt.want {
+				t.Errorf("part2() = %v, %v, want %v", got, err, tt.want)
 			}
 		})
 	}
 }
 
-// func TestPart2(t *testing.T) {
-// 	tests := []struct {
-// 		name  string
-// 		input string
-// 		want  int
-// 	}{
-// 		{
-// 			name: "example",
-// 			input: `467..114..
-// ...*......
-// ..35..633.
-// ......#...
-// 617*......
-// .....+.58.
-// ..592.....
-// ......755.
-// ...$.*....
-// .664.598..`,
-// 			want: 467835,
-// 		},
-// 	}
-// 	for _, tt := range tests {
-// 		t.Run(tt.name, func(t *testing.T) {
-// 			if got := part2(tt.input); got != tt.want {
-// 				t.Errorf("part2() = %v, want %v", got, tt.want)
-// 			}
-// 		})
-// 	}
-// }
+func TestWithExactly(t *testing.T) {
+	s, err := parseInput(examples[0].Input)
+	if err != nil {
+		t.Fatalf("parseInput() error = %v", err)
+	}
+	tests := []struct {
+		symbol rune
+		n      int
+		want   int
+	}{
+		{'*', 2, 2},
+		{'*', 1, 1},
+		{'#', 1, 1},
+		{'$', 1, 1},
+		{'+', 2, 0},
+	}
+	for _, tt := range tests {
+		if got := len(s.withExactly(tt.symbol, tt.n)); got != tt.want {
+			t.Errorf("len(withExactly(%q, %d)) = %v, want %v", tt.symbol, tt.n, got, tt.want)
+		}
+	}
+}
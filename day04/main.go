@@ -1,8 +1,7 @@
-package main
+package day04
 
 import (
 	_ "embed"
-	"flag"
 	"fmt"
 	"math"
 	"regexp"
@@ -11,38 +10,26 @@ import (
 
 	mapset "github.com/deckarep/golang-set/v2"
 
-	"github.com/basokant/advent-of-code-2023/util"
+	"github.com/basokant/advent-of-code-2023/aoc"
 )
 
 //go:embed input.txt
 var input string
 
 func init() {
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	aoc.Register(aoc.Day{
+		Number:   4,
+		Input:    input,
+		Examples: examples,
+		New:      aoc.Funcs(part1, part2),
+	})
 }
 
-func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
+func part1(input string) (int, error) {
+	winningSets, mySets, err := parseInput(input)
+	if err != nil {
+		return 0, err
 	}
-}
-
-func part1(input string) int {
-	winningSets, mySets := parseInput(input)
 
 	sum := 0
 	for i, mySet := range mySets {
@@ -60,11 +47,14 @@ func part1(input string) int {
 
 		sum += int(math.Round(points))
 	}
-	return sum
+	return sum, nil
 }
 
-func part2(input string) int {
-	winningSets, mySets := parseInput(input)
+func part2(input string) (int, error) {
+	winningSets, mySets, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 	numCardsMap := make(map[int]int)
 	for i := range winningSets {
 		numCardsMap[i] = 1
@@ -88,10 +78,10 @@ func part2(input string) int {
 	for _, numCards := range numCardsMap {
 		totalNumCards += numCards
 	}
-	return totalNumCards
+	return totalNumCards, nil
 }
 
-func parseInput(input string) ([]mapset.Set[int], []mapset.Set[int]) {
+func parseInput(input string) ([]mapset.Set[int], []mapset.Set[int], error) {
 	lines := strings.Split(input, "\n")
 	re := regexp.MustCompile("[0-9]+")
 
@@ -99,7 +89,11 @@ func parseInput(input string) ([]mapset.Set[int], []mapset.Set[int]) {
 	mySets := make([]mapset.Set[int], len(lines))
 
 	for i, line := range lines {
-		cards := strings.Split(strings.Split(line, ": ")[1], " | ")
+		_, numbers, _ := strings.Cut(line, ": ")
+		cards := strings.Split(numbers, " | ")
+		if len(cards) != 2 {
+			return nil, nil, fmt.Errorf("line %d: want \"Card N: winning | mine\", got %q", i+1, line)
+		}
 		winNumsStr := re.FindAllString(cards[0], -1)
 		myCardNumsStr := re.FindAllString(cards[1], -1)
 
@@ -119,5 +113,5 @@ func parseInput(input string) ([]mapset.Set[int], []mapset.Set[int]) {
 		mySets[i] = mySet
 	}
 
-	return winningSets, mySets
+	return winningSets, mySets, nil
 }
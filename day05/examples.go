@@ -0,0 +1,13 @@
+package day05
+
+import (
+	"embed"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+// examples are the inputs used by the tests, selectable with aoc run -example.
+var examples = aoc.MustLoadExamples(testdata)
@@ -1,15 +1,16 @@
-package main
+package day05
 
 import (
 	_ "embed"
-	"flag"
 	"fmt"
+	"math"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 
-	"github.com/basokant/advent-of-code-2023/util"
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/util/interval"
 )
 
 //go:embed input.txt
@@ -54,10 +55,18 @@ func compareMappingWithSrc(mapping Mapping, src int) int {
 	return -1
 }
 
+// Map is one section of the almanac, converting numbers of the from category
+// into numbers of the to category.
 type Map struct {
+	from     string
+	to       string
 	mappings []Mapping
 }
 
+func (m Map) String() string {
+	return m.from + "-to-" + m.to
+}
+
 func (m Map) getDest(src int) int {
 	mappingIndex, found := slices.BinarySearchFunc(m.mappings, src, compareMappingWithSrc)
 	if !found {
@@ -66,42 +75,173 @@ func (m Map) getDest(src int) int {
 	return m.mappings[mappingIndex].getDest(src)
 }
 
+// source returns the range of numbers the mapping applies to.
+func (m Mapping) source() interval.Interval {
+	return interval.Length(m.src, m.rangeLen)
+}
+
+// ApplyRanges maps every number in ranges through m. Parts of ranges covered by
+// a mapping are shifted as a whole, and the rest map to themselves, so the
+// work done depends on the number of ranges rather than their lengths.
+func (m Map) ApplyRanges(ranges interval.Set) interval.Set {
+	mapped := []interval.Interval{}
+	for _, mapping := range m.mappings {
+		covered := ranges.Intersect(interval.Union(mapping.source()))
+		for _, r := range covered.Intervals() {
+			mapped = append(mapped, r.Shift(mapping.dest-mapping.src))
+		}
+		ranges = ranges.Difference(covered)
+	}
+	return ranges.Union(interval.Union(mapped...))
+}
+
+// shift returns how far the mapping moves the numbers it applies to.
+func (m Mapping) shift() int {
+	return m.dest - m.src
+}
+
+// Compose returns the single Map equivalent to applying maps in order, such
+// as seed-to-soil through humidity-to-location giving seed-to-location.
+func Compose(maps ...Map) Map {
+	composed := Map{}
+	for _, m := range maps {
+		composed = composed.then(m)
+	}
+	if len(maps) > 0 {
+		composed.from = maps[0].from
+	}
+	return composed
+}
+
+// then returns the Map applying m and then next. Every mapping of m is split
+// by the mappings of next that its destination falls into, and mappings of
+// next are kept where m maps numbers to themselves.
+func (m Map) then(next Map) Map {
+	mappings := []Mapping{}
+	add := func(r interval.Interval, shift int) {
+		if shift != 0 && !r.Empty() {
+			mappings = append(mappings, Mapping{r.Start, r.Start + shift, r.Len()})
+		}
+	}
+
+	for _, first := range m.mappings {
+		image := interval.Union(first.source().Shift(first.shift()))
+		for _, second := range next.mappings {
+			covered := image.Intersect(interval.Union(second.source()))
+			for _, r := range covered.Intervals() {
+				add(r.Shift(-first.shift()), first.shift()+second.shift())
+			}
+			image = image.Difference(covered)
+		}
+		for _, r := range image.Intervals() {
+			add(r.Shift(-first.shift()), first.shift())
+		}
+	}
+
+	sources := m.sources()
+	for _, second := range next.mappings {
+		for _, r := range interval.Union(second.source()).Difference(sources).Intervals() {
+			add(r, second.shift())
+		}
+	}
+
+	composed := NewMap(mappings)
+	composed.from, composed.to = m.from, next.to
+	return composed
+}
+
+// sources returns the numbers m does not map to themselves.
+func (m Map) sources() interval.Set {
+	sources := make([]interval.Interval, len(m.mappings))
+	for i, mapping := range m.mappings {
+		sources[i] = mapping.source()
+	}
+	return interval.Union(sources...)
+}
+
+// Invert returns the Map taking each output of m back to its input, such as
+// location-to-seed from seed-to-location. Only a map that is one-to-one can
+// be inverted: its mappings must move a set of numbers onto itself.
+func (m Map) Invert() (Map, error) {
+	inverse := make([]Mapping, len(m.mappings))
+	for i, mapping := range m.mappings {
+		inverse[i] = Mapping{mapping.dest, mapping.src, mapping.rangeLen}
+	}
+	inverted := NewMap(inverse)
+	inverted.from, inverted.to = m.to, m.from
+
+	sources, dests := m.sources(), inverted.sources()
+	if sources.Len() != m.length() || dests.Len() != m.length() ||
+		!slices.Equal(sources.Intervals(), dests.Intervals()) {
+		return Map{}, fmt.Errorf("%v map is not one-to-one", m)
+	}
+	return inverted, nil
+}
+
+// length returns the total length of the mappings in m.
+func (m Map) length() int {
+	length := 0
+	for _, mapping := range m.mappings {
+		length += mapping.rangeLen
+	}
+	return length
+}
+
+// MinOver returns the smallest number m maps any number in r to, or false if r
+// is empty.
+func (m Map) MinOver(r interval.Interval) (int, bool) {
+	return m.ApplyRanges(interval.Union(r)).Min()
+}
+
 func NewMap(mappings []Mapping) Map {
 	slices.SortFunc(mappings, compareMappingSrcRange)
-	return Map{mappings}
+	return Map{mappings: mappings}
 }
 
 func init() {
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	aoc.Register(aoc.Day{
+		Number:   5,
+		Input:    input,
+		Examples: examples,
+		New:      func() aoc.Solver { return &solver{} },
+	})
 }
 
-func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
+// Almanac is the parsed puzzle input: the seeds, and the maps converting
+// seeds to locations in the order they must be applied.
+type Almanac struct {
+	seeds []int
+	maps  []Map
+}
 
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
+// solver parses the almanac once so that a malformed input is reported as an
+// error instead of producing an answer from zeros.
+type solver struct {
+	almanac Almanac
+}
+
+func (s *solver) Parse(input string) (err error) {
+	s.almanac, err = parseInput(input)
+	return err
 }
 
-func part1(input string) int {
-	seeds, maps := parseInput(input)
+func (s *solver) Part1() (aoc.Answer, error) {
+	return aoc.Int(part1(s.almanac)), nil
+}
 
-	locations := make([]int, len(seeds))
-	for i, seed := range seeds {
+func (s *solver) Part2() (aoc.Answer, error) {
+	lowestLocation, err := part2(s.almanac)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Int(lowestLocation), nil
+}
+
+func part1(almanac Almanac) int {
+	locations := make([]int, len(almanac.seeds))
+	for i, seed := range almanac.seeds {
 		output := seed
-		for _, m := range maps {
+		for _, m := range almanac.maps {
 			output = m.getDest(output)
 		}
 		locations[i] = output
@@ -111,67 +251,129 @@ func part1(input string) int {
 	return lowestLocation
 }
 
-// TODO: solve day05 part2
-func part2(input string) int {
-	seedRanges, maps := parseInput(input)
-	numSeedRanges := len(seedRanges) / 2
+func part2(almanac Almanac) (int, error) {
+	seedRanges := almanac.seeds
+	if len(seedRanges)%2 != 0 {
+		return 0, fmt.Errorf("%d seed numbers do not make start and length pairs", len(seedRanges))
+	}
+
+	seeds := []interval.Interval{}
+	for i := 0; i < len(seedRanges); i += 2 {
+		seeds = append(seeds, interval.Length(seedRanges[i], seedRanges[i+1]))
+	}
 
-	locations := []int{}
-	for i := 0; i < numSeedRanges; i++ {
-		startSeed := seedRanges[2*i]
-		rangeLen := seedRanges[(2*i)+1]
+	seedToLocation := Compose(almanac.maps...)
 
-		for seed := startSeed; seed < startSeed+rangeLen; seed++ {
-			output := seed
-			for _, m := range maps {
-				output = m.getDest(output)
-			}
-			locations = append(locations, output)
+	lowestLocation := math.MaxInt
+	for _, r := range seeds {
+		if location, ok := seedToLocation.MinOver(r); ok {
+			lowestLocation = min(lowestLocation, location)
 		}
 	}
-
-	lowestLocation := slices.Min(locations)
-	return lowestLocation
+	return lowestLocation, nil
 }
 
-func parseInput(input string) ([]int, []Map) {
-	re := regexp.MustCompile("[0-9]+")
-	seedsLine := strings.Split(input, "\n")[0]
+var headerPattern = regexp.MustCompile(`^([a-z]+)-to-([a-z]+) map:$`)
 
-	seedsStr := re.FindAllString(seedsLine, -1)
-	seeds := make([]int, len(seedsStr))
+// parseInput parses the almanac and chains its maps from seed to location by
+// their category names, so the sections may come in any order. Errors name
+// the line they were found on.
+func parseInput(input string) (Almanac, error) {
+	lines := strings.Split(input, "\n")
 
-	for i, seedStr := range seedsStr {
-		seedNum, _ := strconv.Atoi(seedStr)
-		seeds[i] = seedNum
+	seedsLine, ok := strings.CutPrefix(lines[0], "seeds:")
+	if !ok {
+		return Almanac{}, fmt.Errorf("line 1: want seeds, got %q", lines[0])
+	}
+	seeds, err := parseNumbers(seedsLine)
+	if err != nil {
+		return Almanac{}, fmt.Errorf("line 1: %w", err)
+	}
+	if len(seeds) == 0 {
+		return Almanac{}, fmt.Errorf("line 1: no seeds")
 	}
 
-	mapsInput := strings.Split(input, "\n\n")
-	maps := make([]Map, len(mapsInput)-1)
+	maps := map[string]Map{}
+	for i := 1; i < len(lines); i++ {
+		if lines[i] == "" {
+			continue
+		}
 
-	for i, mapInput := range mapsInput[1:] {
-		maps[i] = parseMapInput(mapInput)
-	}
+		header := headerPattern.FindStringSubmatch(lines[i])
+		if header == nil {
+			return Almanac{}, fmt.Errorf("line %d: want a map header like \"seed-to-soil map:\", got %q", i+1, lines[i])
+		}
+		if _, ok := maps[header[1]]; ok {
+			return Almanac{}, fmt.Errorf("line %d: second map from %s", i+1, header[1])
+		}
 
-	return seeds, maps
-}
+		start := i + 1
+		for i+1 < len(lines) && lines[i+1] != "" {
+			i += 1
+		}
+		m, err := parseMapInput(lines[start:i+1], start+1)
+		if err != nil {
+			return Almanac{}, fmt.Errorf("%s-to-%s map: %w", header[1], header[2], err)
+		}
+		m.from, m.to = header[1], header[2]
+		maps[m.from] = m
+	}
 
-func parseMapInput(mapInput string) Map {
-	lines := strings.Split(mapInput, "\n")
+	chain := []Map{}
+	for category := "seed"; category != "location"; {
+		m, ok := maps[category]
+		if !ok {
+			return Almanac{}, fmt.Errorf("no map from %s, so seeds cannot be converted to locations", category)
+		}
+		if len(chain) == len(maps) {
+			return Almanac{}, fmt.Errorf("maps from seed loop back to %s without reaching location", category)
+		}
+		chain = append(chain, m)
+		category = m.to
+	}
 
-	mappings := make([]Mapping, len(lines)-1)
+	return Almanac{seeds, chain}, nil
+}
 
-	for i, line := range lines[1:] {
-		nums := strings.Split(line, " ")
-		dest, _ := strconv.Atoi(nums[0])
-		src, _ := strconv.Atoi(nums[1])
-		rangeLen, _ := strconv.Atoi(nums[2])
+// parseMapInput parses the ranges of one map, the first of which is on line
+// firstLine of the input.
+func parseMapInput(lines []string, firstLine int) (Map, error) {
+	mappings := make([]Mapping, len(lines))
+	for i, line := range lines {
+		nums, err := parseNumbers(line)
+		if err != nil {
+			return Map{}, fmt.Errorf("line %d: %w", firstLine+i, err)
+		}
+		if len(nums) != 3 {
+			return Map{}, fmt.Errorf("line %d: want destination, source and length, got %q", firstLine+i, line)
+		}
 		mappings[i] = Mapping{
-			src,
-			dest,
-			rangeLen,
+			src:      nums[1],
+			dest:     nums[0],
+			rangeLen: nums[2],
+		}
+	}
+
+	for i, a := range mappings {
+		for j, b := range mappings[:i] {
+			if a.source().Overlaps(b.source()) {
+				return Map{}, fmt.Errorf("line %d: source range %v overlaps %v on line %d", firstLine+i, a.source(), b.source(), firstLine+j)
+			}
 		}
 	}
 
-	return NewMap(mappings)
+	return NewMap(mappings), nil
+}
+
+// parseNumbers parses space separated non-negative numbers.
+func parseNumbers(s string) ([]int, error) {
+	nums := []int{}
+	for _, field := range strings.Fields(s) {
+		num, err := strconv.Atoi(field)
+		if err != nil || num < 0 {
+			return nil, fmt.Errorf("invalid number %q", field)
+		}
+		nums = append(nums, num)
+	}
+	return nums, nil
 }
@@ -1,110 +1,213 @@
-package main
+package day05
 
 import (
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/aoc/aoctest"
+	"github.com/basokant/advent-of-code-2023/util/interval"
 )
 
+func mustParse(t *testing.T, input string) Almanac {
+	t.Helper()
+	almanac, err := parseInput(input)
+	if err != nil {
+		t.Fatalf("parseInput() error = %v", err)
+	}
+	return almanac
+}
+
 func TestPart1(t *testing.T) {
+	aoctest.Examples(t, 5, 1)
+}
+
+func TestPart2(t *testing.T) {
+	aoctest.Examples(t, 5, 2)
+}
+
+func TestApplyRanges(t *testing.T) {
+	maps := mustParse(t, examples[0].Input).maps
+
+	for _, m := range maps {
+		ranges := interval.Union(interval.New(0, 100))
+		got := m.ApplyRanges(ranges)
+		if got.Len() > ranges.Len() {
+			t.Errorf("ApplyRanges() = %v, more numbers than %v", got, ranges)
+		}
+		for n := 0; n < 100; n++ {
+			if dest := m.getDest(n); !got.Contains(dest) {
+				t.Errorf("ApplyRanges() = %v, missing getDest(%d) = %d", got, n, dest)
+			}
+		}
+	}
+}
+
+func applyAll(maps []Map, n int) int {
+	for _, m := range maps {
+		n = m.getDest(n)
+	}
+	return n
+}
+
+func TestCompose(t *testing.T) {
 	tests := []struct {
 		name  string
 		input string
-		want  int
 	}{
+		{"example", examples[0].Input},
 		{
-			name: "example",
-			input: `seeds: 79 14 55 13
-
-seed-to-soil map:
-50 98 2
-52 50 48
-
-soil-to-fertilizer map:
-0 15 37
-37 52 2
-39 0 15
-
-fertilizer-to-water map:
-49 53 8
-0 11 42
-42 0 7
-57 7 4
-
-water-to-light map:
-88 18 7
-18 25 70
-
-light-to-temperature map:
-45 77 23
-81 45 19
-68 64 13
-
-temperature-to-humidity map:
-0 69 1
-1 0 69
-
-humidity-to-location map:
-60 56 37
-56 93 4`,
-			want: 35,
+			// Ranges that split, overlap and leave gaps in the next map's
+			// sources, including one mapped onto a number another maps from.
+			"overlapping ranges",
+			"seeds: 0 10 25 60\n\n" +
+				"seed-to-soil map:\n50 0 20\n0 20 5\n\n" +
+				"soil-to-fertilizer map:\n100 45 10\n3 60 5\n\n" +
+				"fertilizer-to-location map:\n0 100 3\n20 2 4",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := part1(tt.input); got != tt.want {
-				t.Errorf("part1() = %v, want %v", got, tt.want)
+			almanac := mustParse(t, tt.input)
+			seeds, maps := almanac.seeds, almanac.maps
+			composed := Compose(maps...)
+
+			for _, seed := range seeds {
+				for n := seed - 50; n < seed+50; n++ {
+					if got, want := composed.getDest(n), applyAll(maps, n); got != want {
+						t.Fatalf("Compose().getDest(%d) = %v, want %v", n, got, want)
+					}
+				}
+			}
+			for _, m := range composed.mappings {
+				for _, n := range []int{m.src - 1, m.src, m.src + m.rangeLen - 1, m.src + m.rangeLen} {
+					if got, want := composed.getDest(n), applyAll(maps, n); got != want {
+						t.Fatalf("Compose().getDest(%d) = %v, want %v", n, got, want)
+					}
+				}
 			}
 		})
 	}
 }
 
-func TestPart2(t *testing.T) {
+func TestComposeRanges(t *testing.T) {
+	maps := mustParse(t, examples[0].Input).maps
+	ranges := interval.Union(interval.New(0, 120))
+
+	want := ranges
+	for _, m := range maps {
+		want = m.ApplyRanges(want)
+	}
+	if got := Compose(maps...).ApplyRanges(ranges); !slices.Equal(got.Intervals(), want.Intervals()) {
+		t.Errorf("Compose().ApplyRanges() = %v, want %v", got, want)
+	}
+
+	if got, ok := Compose(maps...).MinOver(interval.Length(79, 14)); !ok || got != 46 {
+		t.Errorf("MinOver([79, 93)) = %v, %v, want 46", got, ok)
+	}
+}
+
+func TestInvert(t *testing.T) {
+	maps := mustParse(t, examples[0].Input).maps
+	seedToLocation := Compose(maps...)
+
+	locationToSeed, err := seedToLocation.Invert()
+	if err != nil {
+		t.Fatalf("Invert() error = %v", err)
+	}
+	for seed := 0; seed < 120; seed++ {
+		location := seedToLocation.getDest(seed)
+		if got := locationToSeed.getDest(location); got != seed {
+			t.Errorf("Invert().getDest(%d) = %v, want %v", location, got, seed)
+		}
+	}
+
+	if _, err := NewMap([]Mapping{{src: 0, dest: 10, rangeLen: 5}}).Invert(); err == nil {
+		t.Errorf("Invert() of a map that is not one-to-one succeeded")
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	almanac := mustParse(t, examples[0].Input)
+	names := []string{}
+	for _, m := range almanac.maps {
+		names = append(names, m.String())
+	}
+	want := []string{"seed-to-soil", "soil-to-fertilizer", "fertilizer-to-water", "water-to-light",
+		"light-to-temperature", "temperature-to-humidity", "humidity-to-location"}
+	if !slices.Equal(names, want) {
+		t.Errorf("parseInput() maps = %v, want %v", names, want)
+	}
+	if got := Compose(almanac.maps...).String(); got != "seed-to-location" {
+		t.Errorf("Compose() = %v, want seed-to-location", got)
+	}
+
+	sections := strings.Split(examples[0].Input, "\n\n")
+	slices.Reverse(sections[1:])
+	got := part1(mustParse(t, strings.Join(sections, "\n\n")))
+	if want := examples[0].Answers[0]; !want.Equal(aoc.Int(got)) {
+		t.Errorf("part1() with sections reversed = %v, want %v", got, want)
+	}
+}
+
+func TestParseInputErrors(t *testing.T) {
 	tests := []struct {
 		name  string
 		input string
-		want  int
+		want  string
 	}{
 		{
-			name: "example",
-			input: `seeds: 79 14 55 13
-
-seed-to-soil map:
-50 98 2
-52 50 48
-
-soil-to-fertilizer map:
-0 15 37
-37 52 2
-39 0 15
-
-fertilizer-to-water map:
-49 53 8
-0 11 42
-42 0 7
-57 7 4
-
-water-to-light map:
-88 18 7
-18 25 70
-
-light-to-temperature map:
-45 77 23
-81 45 19
-68 64 13
-
-temperature-to-humidity map:
-0 69 1
-1 0 69
-
-humidity-to-location map:
-60 56 37
-56 93 4`,
-			want: 46,
+			name:  "missing seeds",
+			input: "seed-to-location map:\n1 2 3",
+			want:  "line 1: want seeds",
+		},
+		{
+			name:  "bad seed",
+			input: "seeds: 1 x",
+			want:  `line 1: invalid number "x"`,
+		},
+		{
+			name:  "bad header",
+			input: "seeds: 1\n\nseed to location:\n1 2 3",
+			want:  "line 3: want a map header",
+		},
+		{
+			name:  "bad number",
+			input: "seeds: 1\n\nseed-to-location map:\n1 2 3\n4 five 6",
+			want:  `seed-to-location map: line 5: invalid number "five"`,
+		},
+		{
+			name:  "short range",
+			input: "seeds: 1\n\nseed-to-location map:\n1 2",
+			want:  "seed-to-location map: line 4: want destination, source and length",
+		},
+		{
+			name:  "overlapping sources",
+			input: "seeds: 1\n\nseed-to-location map:\n0 10 5\n20 14 2",
+			want:  "seed-to-location map: line 5: source range [14, 16) overlaps [10, 15) on line 4",
+		},
+		{
+			name:  "duplicate section",
+			input: "seeds: 1\n\nseed-to-soil map:\n\nseed-to-location map:",
+			want:  "line 5: second map from seed",
+		},
+		{
+			name:  "gap in chain",
+			input: "seeds: 1\n\nseed-to-soil map:\n1 2 3\n\nwater-to-location map:\n1 2 3",
+			want:  "no map from soil",
+		},
+		{
+			name:  "loop in chain",
+			input: "seeds: 1\n\nseed-to-soil map:\n\nsoil-to-seed map:",
+			want:  "loop back to seed",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := part2(tt.input); got != tt.want {
-				t.Errorf("part2() = %v, want %v", got, tt.want)
+			_, err := parseInput(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseInput() error = %v, want %q", err, tt.want)
 			}
 		})
 	}
@@ -1,112 +1,179 @@
-package main
+package day06
 
 import (
 	_ "embed"
-	"flag"
 	"fmt"
+	"math/big"
 	"regexp"
-	"strconv"
 	"strings"
 
-	"github.com/basokant/advent-of-code-2023/util"
+	"github.com/basokant/advent-of-code-2023/aoc"
 )
 
 //go:embed input.txt
 var input string
 
 func init() {
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
+	aoc.Register(aoc.Day{
+		Number:   6,
+		Input:    input,
+		Examples: examples,
+		New:      aoc.Funcs(part1, part2),
+	})
+}
+
+func part1(input string) (*big.Int, error) {
+	races, err := parseInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	numWinPossibilities := big.NewInt(1)
+	for _, race := range races {
+		numWinPossibilities.Mul(numWinPossibilities, race.countWins())
 	}
+
+	return numWinPossibilities, nil
 }
 
-func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
+func part2(input string) (*big.Int, error) {
+	race, err := parseInputPart2(input)
+	if err != nil {
+		return nil, err
 	}
+	return race.countWins(), nil
 }
 
-func part1(input string) int {
-	races := parseInput(input)
+// Race is a record distance to beat within a time limit. They are big
+// numbers since the part 2 race, made by concatenating every race's digits,
+// can overflow an int.
+type Race struct {
+	time     *big.Int
+	distance *big.Int
+}
 
-	numWinPossibilities := 1
-	for _, race := range races {
-		numWinSpeeds := 0
-		for speed := 0; speed <= race.time; speed++ {
-			distance := computeDistance(speed, race.time)
-			if distance > race.distance {
-				numWinSpeeds += 1
-			}
-		}
-		numWinPossibilities *= numWinSpeeds
+func computeDistance(speed *big.Int, time *big.Int) *big.Int {
+	remaining := new(big.Int).Sub(time, speed)
+	return remaining.Mul(remaining, speed)
+}
+
+func (r Race) wins(speed *big.Int) bool {
+	return computeDistance(speed, r.time).Cmp(r.distance) > 0
+}
+
+// winningSpeeds returns the inclusive interval of speeds, which are also the
+// times spent holding the button, that beat the record. ok is false if none
+// do.
+//
+// Holding for h beats the record d when h*(t-h) > d, which is when h lies
+// strictly between the roots of h^2 - t*h + d, (t ± sqrt(t^2 - 4d)) / 2. The
+// integer square root puts the estimate within one of the exact bound, which
+// is then found by checking the neighbouring speeds.
+func (r Race) winningSpeeds() (lo *big.Int, hi *big.Int, ok bool) {
+	discriminant := new(big.Int).Mul(r.time, r.time)
+	discriminant.Sub(discriminant, new(big.Int).Lsh(r.distance, 2))
+	if discriminant.Sign() < 0 {
+		return nil, nil, false
+	}
+
+	// The distance peaks at h = t/2, so if no integer speed wins there, none
+	// wins at all, even when the roots are real. Otherwise lo reaches a
+	// winning speed by t/2 at the latest.
+	half := new(big.Int).Rsh(r.time, 1)
+	if !r.wins(half) {
+		return nil, nil, false
+	}
+
+	one := big.NewInt(1)
+	lo = new(big.Int).Sub(r.time, new(big.Int).Sqrt(discriminant))
+	lo.Rsh(lo, 1)
+	for lo.Sign() > 0 && r.wins(new(big.Int).Sub(lo, one)) {
+		lo.Sub(lo, one)
+	}
+	for lo.Cmp(half) < 0 && !r.wins(lo) {
+		lo.Add(lo, one)
 	}
 
-	return numWinPossibilities
+	// Distance is symmetric in h and t-h, so the winning speeds are too.
+	hi = new(big.Int).Sub(r.time, lo)
+	return lo, hi, true
 }
 
-func part2(input string) int {
-	race := parseInputPart2(input)
+// countWins returns the number of speeds that beat the record.
+func (r Race) countWins() *big.Int {
+	lo, hi, ok := r.winningSpeeds()
+	if !ok {
+		return big.NewInt(0)
+	}
+	count := new(big.Int).Sub(hi, lo)
+	return count.Add(count, big.NewInt(1))
+}
 
-	numWinSpeeds := 0
-	for speed := 0; speed <= race.time; speed++ {
-		distance := computeDistance(speed, race.time)
-		if distance > race.distance {
-			numWinSpeeds += 1
+// countWinsBruteForce is countWins trying every speed, to cross-check it in
+// tests.
+func (r Race) countWinsBruteForce() *big.Int {
+	numWinSpeeds := big.NewInt(0)
+	one := big.NewInt(1)
+	for speed := big.NewInt(0); speed.Cmp(r.time) <= 0; speed.Add(speed, one) {
+		if r.wins(speed) {
+			numWinSpeeds.Add(numWinSpeeds, one)
 		}
 	}
-
 	return numWinSpeeds
 }
 
-type Race struct {
-	time     int
-	distance int
-}
+var numberPattern = regexp.MustCompile("[0-9]+")
 
-func computeDistance(speed int, time int) int {
-	return speed * (time - speed)
+func parseNumber(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
 }
 
-func parseInput(input string) []Race {
+// parseRecords returns the numbers on the time and distance lines.
+func parseRecords(input string) ([]string, []string, error) {
 	lines := strings.Split(input, "\n")
-	re := regexp.MustCompile("[0-9]+")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "Time:") || !strings.HasPrefix(lines[1], "Distance:") {
+		return nil, nil, fmt.Errorf("want a Time: line and a Distance: line, got %q", input)
+	}
 
-	timesStr := re.FindAllString(lines[0], -1)
-	distancesStr := re.FindAllString(lines[1], -1)
+	times := numberPattern.FindAllString(lines[0], -1)
+	distances := numberPattern.FindAllString(lines[1], -1)
+	if len(times) == 0 || len(distances) == 0 {
+		return nil, nil, fmt.Errorf("no races in %q", input)
+	}
+
+	return times, distances, nil
+}
+
+func parseInput(input string) ([]Race, error) {
+	timesStr, distancesStr, err := parseRecords(input)
+	if err != nil {
+		return nil, err
+	}
+	// Each time is paired with the distance at the same position, and any
+	// distances beyond the last time are ignored, as they always have been.
+	if len(distancesStr) < len(timesStr) {
+		return nil, fmt.Errorf("%d times but only %d distances", len(timesStr), len(distancesStr))
+	}
 
 	races := []Race{}
 
 	for i, timeStr := range timesStr {
-		time, _ := strconv.Atoi(timeStr)
-		distance, _ := strconv.Atoi(distancesStr[i])
-		race := Race{time, distance}
+		race := Race{parseNumber(timeStr), parseNumber(distancesStr[i])}
 		races = append(races, race)
 	}
 
-	return races
+	return races, nil
 }
 
-func parseInputPart2(input string) Race {
-	lines := strings.Split(input, "\n")
-	re := regexp.MustCompile("[0-9]+")
-
-	timesDigits := re.FindAllString(lines[0], -1)
-	distancesDigits := re.FindAllString(lines[1], -1)
+func parseInputPart2(input string) (Race, error) {
+	timesDigits, distancesDigits, err := parseRecords(input)
+	if err != nil {
+		return Race{}, err
+	}
 
-	time, _ := strconv.Atoi(strings.Join(timesDigits, ""))
-	distance, _ := strconv.Atoi(strings.Join(distancesDigits, ""))
+	time := parseNumber(strings.Join(timesDigits, ""))
+	distance := parseNumber(strings.Join(distancesDigits, ""))
 
-	return Race{time, distance}
+	return Race{time, distance}, nil
 }
@@ -1,49 +1,77 @@
-package main
+package day06
 
 import (
+	"math/big"
 	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc/aoctest"
 )
 
 func TestPart1(t *testing.T) {
+	aoctest.Examples(t, 6, 1)
+}
+
+func TestPart2(t *testing.T) {
+	aoctest.Examples(t, 6, 2)
+}
+
+func TestWinningSpeeds(t *testing.T) {
 	tests := []struct {
-		name  string
-		input string
-		want  int
+		time, distance int64
+		lo, hi         int64
+		ok             bool
 	}{
-		{
-			name: "test1",
-			input: `Time:      7  15   30
-Distance:  9  40  200`,
-			want: 288,
-		},
+		{7, 9, 2, 5, true},
+		{15, 40, 4, 11, true},
+		{30, 200, 11, 19, true},
+		{4, 4, 0, 0, false},
+		{4, 3, 2, 2, true},
+		{0, 0, 0, 0, false},
+		{5, 6, 0, 0, false},
+		// The record is the best distance, (t/2)^2, so the roots are real
+		// but no speed beats it.
+		{2e8, 1e16, 0, 0, false},
+		{2e8, 1e16 - 1, 1e8, 1e8, true},
 	}
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := part1(tt.input); got != tt.want {
-				t.Errorf("part1() = %v, want %v", got, tt.want)
+		race := Race{big.NewInt(tt.time), big.NewInt(tt.distance)}
+		lo, hi, ok := race.winningSpeeds()
+		if ok != tt.ok || ok && (lo.Int64() != tt.lo || hi.Int64() != tt.hi) {
+			t.Errorf("winningSpeeds(%d, %d) = %v, %v, %v, want %v, %v, %v",
+				tt.time, tt.distance, lo, hi, ok, tt.lo, tt.hi, tt.ok)
+		}
+	}
+}
+
+func TestCountWinsMatchesBruteForce(t *testing.T) {
+	for time := int64(0); time <= 60; time++ {
+		for distance := int64(0); distance <= time*time/4+1; distance++ {
+			race := Race{big.NewInt(time), big.NewInt(distance)}
+			if got, want := race.countWins(), race.countWinsBruteForce(); got.Cmp(want) != 0 {
+				t.Errorf("countWins(%d, %d) = %v, want %v", time, distance, got, want)
 			}
-		})
+		}
 	}
 }
 
-func TestPart2(t *testing.T) {
-	tests := []struct {
-		name  string
-		input string
-		want  int
-	}{
-		{
-			name: "test1",
-			input: `Time:      7  15   30
-Distance:  9  40  200`,
-			want: 71503,
-		},
+func TestCountWinsOverflowingInt(t *testing.T) {
+	// Beating a record of t^2/4 - k^2 for even t needs a speed within k of
+	// t/2, leaving 2k-1 winning speeds whatever the size of t.
+	time, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	half := new(big.Int).Rsh(time, 1)
+	distance := new(big.Int).Mul(half, half)
+	distance.Sub(distance, big.NewInt(1000*1000))
+
+	got := Race{time, distance}.countWins()
+	if got.Int64() != 1999 {
+		t.Errorf("countWins() = %v, want 1999", got)
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := part2(tt.input); got != tt.want {
-				t.Errorf("part2() = %v, want %v", got, tt.want)
-			}
-		})
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{"", "Time: 7 15\nDistance: 9", "Distance: 9\nTime: 7"} {
+		if _, err := part1(input); err == nil {
+			t.Errorf("part1(%q) error = nil, want error", input)
+		}
 	}
 }
@@ -0,0 +1,59 @@
+package day07
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+// Ranking explains the place of one hand in the total winnings.
+type Ranking struct {
+	Rank  int    `json:"rank"`
+	Hand  string `json:"hand"`
+	Class string `json:"class"`
+	// Substitution is the hand with its jokers replaced by the cards that
+	// make its class, or empty if it has no jokers.
+	Substitution string `json:"substitution,omitempty"`
+	Bid          int    `json:"bid"`
+	Winnings     int    `json:"winnings"`
+}
+
+func explain(hands []Hand, rules Rules) []Ranking {
+	rankings := []Ranking{}
+	for i, hand := range rankHands(hands) {
+		ranking := Ranking{
+			Rank:     i + 1,
+			Hand:     rules.FormatHand(hand.cards),
+			Class:    rules.Classes[hand.rating.Class].Name,
+			Bid:      hand.bid,
+			Winnings: (i + 1) * hand.bid,
+		}
+		if slices.ContainsFunc(hand.cards, rules.isWild) {
+			_, substituted := rules.substitute(hand.cards)
+			ranking.Substitution = rules.FormatHand(substituted)
+		}
+		rankings = append(rankings, ranking)
+	}
+	return rankings
+}
+
+func (s *solver) Explain(w io.Writer, part int, format aoc.ExplainFormat) error {
+	rankings := explain(s.hands[part-1], partRules(part))
+
+	if format == aoc.ExplainJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rankings)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "rank\thand\tclass\tjokers as\tbid\twinnings\t")
+	for _, r := range rankings {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%d\t\n", r.Rank, r.Hand, r.Class, r.Substitution, r.Bid, r.Winnings)
+	}
+	return tw.Flush()
+}
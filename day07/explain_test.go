@@ -0,0 +1,68 @@
+package day07
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+func mustParse(t *testing.T, input string, rules Rules) []Hand {
+	t.Helper()
+	hands, err := parseInput(input, rules)
+	if err != nil {
+		t.Fatalf("parseInput() error = %v", err)
+	}
+	return hands
+}
+
+func TestExplain(t *testing.T) {
+	rankings := explain(mustParse(t, examples[0].Input, CamelCardsJokers), CamelCardsJokers)
+
+	total := 0
+	for i, r := range rankings {
+		if r.Rank != i+1 {
+			t.Errorf("rankings[%d].Rank = %v, want %v", i, r.Rank, i+1)
+		}
+		total += r.Winnings
+	}
+	if want := examples[0].Answers[1]; !want.Equal(aoc.Int(total)) {
+		t.Errorf("total winnings = %v, want %v", total, want)
+	}
+
+	want := Ranking{Rank: 5, Hand: "KTJJT", Class: "four of a kind", Substitution: "KTTTT", Bid: 220, Winnings: 1100}
+	if got := rankings[4]; got != want {
+		t.Errorf("rankings[4] = %+v, want %+v", got, want)
+	}
+	if got := rankings[0]; got.Hand != "32T3K" || got.Substitution != "" {
+		t.Errorf("rankings[0] = %+v, want 32T3K without jokers", got)
+	}
+}
+
+func TestSolverExplain(t *testing.T) {
+	s := &solver{}
+	if err := s.Parse(examples[0].Input); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var b strings.Builder
+	if err := s.Explain(&b, 1, aoc.ExplainJSON); err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	var rankings []Ranking
+	if err := json.Unmarshal([]byte(b.String()), &rankings); err != nil {
+		t.Fatalf("Explain() wrote invalid JSON: %v", err)
+	}
+	if len(rankings) != 5 || rankings[4].Hand != "QQQJA" || rankings[4].Class != "three of a kind" {
+		t.Errorf("Explain() = %+v, want QQQJA strongest", rankings)
+	}
+
+	b.Reset()
+	if err := s.Explain(&b, 2, aoc.ExplainTable); err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(b.String()), "\n"); len(lines) != 6 || !strings.Contains(lines[0], "jokers as") {
+		t.Errorf("Explain() table =\n%s", b.String())
+	}
+}
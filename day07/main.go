@@ -1,281 +1,78 @@
-package main
+package day07
 
 import (
 	_ "embed"
-	"flag"
 	"fmt"
 	"slices"
 	"strconv"
 	"strings"
-	"unicode"
 
-	"github.com/basokant/advent-of-code-2023/util"
+	"github.com/basokant/advent-of-code-2023/aoc"
 )
 
 //go:embed input.txt
 var input string
 
 func init() {
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
+	aoc.Register(aoc.Day{
+		Number:   7,
+		Input:    input,
+		Examples: examples,
+		New:      func() aoc.Solver { return &solver{} },
+	})
 }
 
-type HandClass int
-
-const (
-	HighCard HandClass = iota
-	OnePair
-	TwoPair
-	ThreeOfAKind
-	FullHouse
-	FourOfAKind
-	FiveOfAKind
-)
-
 type Hand struct {
-	cards []rune
-	class HandClass
-	bid   int
+	cards  []Card
+	rating Rating
+	bid    int
 }
 
-func getHandClass(cards []rune, useJokers bool) HandClass {
-	if len(cards) != 5 {
-		panic("Hand does not have 5 cards, " + string(cards))
-	}
-
-	cardCounts := make(map[rune]int)
-	jokers := 0
-	for _, card := range cards {
-		if useJokers && card == 'J' {
-			jokers += 1
-		} else {
-			cardCounts[card] += 1
-		}
-	}
-
-	maxOfAKind := getMaxOfAKind(cardCounts, jokers)
-	switch true {
-	case maxOfAKind == 5:
-		return FiveOfAKind
-	case maxOfAKind == 4:
-		return FourOfAKind
-	case isFullHouse(cardCounts, jokers):
-		return FullHouse
-	case maxOfAKind == 3:
-		return ThreeOfAKind
-	case isTwoPair(cardCounts, jokers):
-		return TwoPair
-	case maxOfAKind == 2:
-		return OnePair
-	default:
-		return HighCard
-	}
-}
-
-func getMaxOfAKind(cardCounts map[rune]int, jokers int) int {
-	counts := util.Values(cardCounts)
-	maxOfAKind := 0
-	if len(counts) > 0 {
-		maxOfAKind = slices.Max(counts)
-	}
-	maxOfAKind += jokers
-	return maxOfAKind
+func compareHands(a Hand, b Hand) int {
+	return CompareRatings(a.rating, b.rating)
 }
 
-func isFullHouse(cardCounts map[rune]int, jokers int) bool {
-	useJokers := jokers > 0
-	if (!useJokers && len(cardCounts) != 2) || (useJokers && 1 <= len(cardCounts) && 3 <= len(cardCounts)) {
-		return false
-	}
-
-	singleCount, pairCount, threesCount := 0, 0, 0
-	for _, count := range cardCounts {
-		switch count {
-		case 1:
-			singleCount += 1
-		case 2:
-			pairCount += 1
-		case 3:
-			threesCount += 1
-		}
-	}
-
-	if !useJokers && len(cardCounts) == 2 || useJokers && jokers == 0 {
-		return pairCount == 1 && threesCount == 1
-	} else if useJokers {
-		switch jokers {
-		case 1:
-			return threesCount == 1 || pairCount == 2
-		case 2:
-			return threesCount == 1 || pairCount == 1
-		case 3, 4, 5:
-			return true
-		}
-	}
-
-	return false
+// solver parses the hands under the rules of each part, so that it can also
+// explain the ranking behind either part's answer.
+type solver struct {
+	hands [2][]Hand
 }
 
-func isTwoPair(cardCounts map[rune]int, jokers int) bool {
-	useJokers := jokers > 0
-	if (!useJokers && len(cardCounts) != 3) || (useJokers && 1 <= len(cardCounts) && 4 <= len(cardCounts)) {
-		return false
-	}
-
-	singleCount, pairCount, threesCount := 0, 0, 0
-	for _, count := range cardCounts {
-		switch count {
-		case 1:
-			singleCount += 1
-		case 2:
-			pairCount += 1
-		case 3:
-			threesCount += 1
-		}
-	}
-
-	if !useJokers && len(cardCounts) == 3 || useJokers && jokers == 0 {
-		return pairCount == 2 && singleCount == 1
-	} else if useJokers {
-		switch jokers {
-		case 1:
-			return pairCount == 1
-		case 2:
-			return threesCount == 0
-		case 3, 4, 5:
-			return true
+func (s *solver) Parse(input string) error {
+	for part := 1; part <= 2; part++ {
+		hands, err := parseInput(input, partRules(part))
+		if err != nil {
+			return err
 		}
+		s.hands[part-1] = hands
 	}
-
-	return false
-}
-
-func getCardRank(card rune, useJokers bool) (int, error) {
-	if unicode.IsDigit(card) {
-		return int(card - '0'), nil
-	}
-
-	switch true {
-	case card == 'T':
-		return 10, nil
-	case !useJokers && card == 'J':
-		return 11, nil
-	case card == 'Q':
-		return 12, nil
-	case card == 'K':
-		return 13, nil
-	case card == 'A':
-		return 14, nil
-	default:
-		return -1, nil
-	}
+	return nil
 }
 
-func compareCards(a rune, b rune) int {
-	aRank, _ := getCardRank(a, false)
-	bRank, _ := getCardRank(b, false)
-
-	if aRank == bRank {
-		return 0
-	} else if aRank > bRank {
-		return 1
-	} else {
-		return -1
-	}
+func (s *solver) Part1() (aoc.Answer, error) {
+	return aoc.Int(totalWinnings(rankHands(s.hands[0]))), nil
 }
 
-func compareCardsWithJokers(a rune, b rune) int {
-	aRank, _ := getCardRank(a, true)
-	bRank, _ := getCardRank(b, true)
-
-	if aRank == bRank {
-		return 0
-	} else if aRank > bRank {
-		return 1
-	} else {
-		return -1
-	}
+func (s *solver) Part2() (aoc.Answer, error) {
+	return aoc.Int(totalWinnings(rankHands(s.hands[1]))), nil
 }
 
-func compareHands(a Hand, b Hand) int {
-	if a.class > b.class {
-		return 1
-	} else if a.class < b.class {
-		return -1
-	}
-
-	for i, aCard := range a.cards {
-		bCard := b.cards[i]
-		comp := compareCards(aCard, bCard)
-		if comp != 0 {
-			return comp
-		}
-	}
-
-	return 0
-}
-
-func compareHandsWithJokers(a Hand, b Hand) int {
-	if a.class > b.class {
-		return 1
-	} else if a.class < b.class {
-		return -1
-	}
-
-	for i, aCard := range a.cards {
-		bCard := b.cards[i]
-		comp := compareCardsWithJokers(aCard, bCard)
-		if comp != 0 {
-			return comp
-		}
+func partRules(part int) Rules {
+	if part == 2 {
+		return CamelCardsJokers
 	}
-
-	return 0
+	return CamelCards
 }
 
-func part1(input string) int {
-	hands := parseInput(input, false)
-	slices.SortFunc[[]Hand, Hand](hands, compareHands)
-
-	for _, hand := range hands {
-		fmt.Printf("hand %s, class %d, bid %d\n", string(hand.cards), hand.class, hand.bid)
-	}
-
-	totalWinnings := 0
-	for i, hand := range hands {
-		totalWinnings += (i + 1) * hand.bid
-	}
-
-	return totalWinnings
+// rankHands returns the hands from weakest to strongest, so each hand's rank
+// is one more than its index.
+func rankHands(hands []Hand) []Hand {
+	ranked := slices.Clone(hands)
+	slices.SortFunc(ranked, compareHands)
+	return ranked
 }
 
-func part2(input string) int {
-	hands := parseInput(input, true)
-	slices.SortFunc[[]Hand, Hand](hands, compareHandsWithJokers)
-
-	for _, hand := range hands {
-		fmt.Printf("hand %s, class %d, bid %d\n", string(hand.cards), hand.class, hand.bid)
-	}
-
+func totalWinnings(hands []Hand) int {
 	totalWinnings := 0
 	for i, hand := range hands {
 		totalWinnings += (i + 1) * hand.bid
@@ -284,25 +81,32 @@ func part2(input string) int {
 	return totalWinnings
 }
 
-func parseInput(input string, useJokers bool) []Hand {
+func parseInput(input string, rules Rules) ([]Hand, error) {
 	lines := strings.Split(input, "\n")
 	hands := []Hand{}
 
-	for _, line := range lines {
-		tokens := strings.Split(line, " ")
-		handInput, bidInput := tokens[0], tokens[1]
+	for i, line := range lines {
+		handInput, bidInput, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("line %d: want a hand and a bid, got %q", i+1, line)
+		}
 
-		bid, _ := strconv.Atoi(bidInput)
-		cards := []rune(handInput)
-		class := getHandClass(cards, useJokers)
+		cards, err := rules.ParseHand(handInput)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		bid, err := strconv.Atoi(bidInput)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid bid %q", i+1, bidInput)
+		}
 
 		hand := Hand{
-			cards: cards,
-			class: class,
-			bid:   bid,
+			cards:  cards,
+			rating: rules.Rate(cards),
+			bid:    bid,
 		}
 		hands = append(hands, hand)
 	}
 
-	return hands
+	return hands, nil
 }
@@ -1,100 +1,52 @@
-package main
+package day07
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc/aoctest"
 )
 
 func TestPart1(t *testing.T) {
+	aoctest.Examples(t, 7, 1)
+}
+
+func TestPart2(t *testing.T) {
+	aoctest.Examples(t, 7, 2)
+}
+
+func TestParseInputErrors(t *testing.T) {
 	tests := []struct {
 		name  string
 		input string
-		want  int
+		want  string
 	}{
 		{
-			name: "test1",
-			input: `32T3K 765
-T55J5 684
-KK677 28
-KTJJT 220
-QQQJA 483`,
-			want: 6440,
+			name:  "missing bid",
+			input: "32T3K 765\nT55J5",
+			want:  `line 2: want a hand and a bid, got "T55J5"`,
 		},
 		{
-			name: "test2",
-			input: `2345A 1
-Q2KJJ 13
-Q2Q2Q 19
-T3T3J 17
-T3Q33 11
-2345J 3
-J345A 2
-32T3K 5
-T55J5 29
-KK677 7
-KTJJT 34
-QQQJA 31
-JJJJJ 37
-JAAAA 43
-AAAAJ 59
-AAAAA 61
-2AAAA 23
-2JJJJ 53
-JJJJ2 41`,
-			want: 6592,
+			name:  "bad bid",
+			input: "32T3K x",
+			want:  `line 1: invalid bid "x"`,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := part1(tt.input); got != tt.want {
-				t.Errorf("part1() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestPart2(t *testing.T) {
-	tests := []struct {
-		name  string
-		input string
-		want  int
-	}{
 		{
-			name: "test1",
-			input: `32T3K 765
-T55J5 684
-KK677 28
-KTJJT 220
-QQQJA 483`,
-			want: 5905,
+			name:  "bad card",
+			input: "32T3X 765",
+			want:  `line 1: invalid camel cards card "X"`,
 		},
 		{
-			name: "test2",
-			input: `2345A 1
-Q2KJJ 13
-Q2Q2Q 19
-T3T3J 17
-T3Q33 11
-2345J 3
-J345A 2
-32T3K 5
-T55J5 29
-KK677 7
-KTJJT 34
-QQQJA 31
-JJJJJ 37
-JAAAA 43
-AAAAJ 59
-AAAAA 61
-2AAAA 23
-2JJJJ 53
-JJJJ2 41`,
-			want: 6839,
+			name:  "short hand",
+			input: "32T3 765",
+			want:  "line 1: camel cards hand \"32T3\" has 4 cards, want 5",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := part2(tt.input); got != tt.want {
-				t.Errorf("part2() = %v, want %v", got, tt.want)
+			err := (&solver{}).Parse(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.want)
 			}
 		})
 	}
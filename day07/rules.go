@@ -0,0 +1,383 @@
+package day07
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Card is a playing card. Games without suits, like Camel Cards, leave Suit
+// zero.
+type Card struct {
+	Rank rune
+	Suit rune
+}
+
+func (c Card) String() string {
+	if c.Suit == 0 {
+		return string(c.Rank)
+	}
+	return string([]rune{c.Rank, c.Suit})
+}
+
+// TieBreak is how two hands of the same class are ordered.
+type TieBreak int
+
+const (
+	// InOrder compares the hands card by card as they were dealt, as Camel
+	// Cards does.
+	InOrder TieBreak = iota
+	// ByGroup compares the ranks of the largest groups of equal cards first
+	// and the kickers last, as poker does.
+	ByGroup
+)
+
+// Class is a kind of hand, such as a full house. A hand is of the class if
+// its cards, with wildcards standing in for any card, meet every condition.
+type Class struct {
+	Name string
+	// Groups are the least sizes of the hand's largest groups of cards of
+	// equal rank, largest first. A full house is {3, 2}.
+	Groups []int
+	// Straight requires the ranks to be one of the rules' straights.
+	Straight bool
+	// Flush requires every card to be of the same suit.
+	Flush bool
+}
+
+// Rules define a card game as data: its cards, wildcards and kinds of hand.
+// Both Camel Cards variants and poker are Rules evaluated by the same code.
+type Rules struct {
+	Name string
+	// Size is the number of cards in a hand.
+	Size int
+	// Ranks are the card ranks from weakest to strongest.
+	Ranks string
+	// Suits are the card suits, or empty if cards have none.
+	Suits string
+	// Wild are the ranks that stand in for whichever card makes the
+	// strongest hand. They still break ties as their own rank.
+	Wild string
+	// Straights are the sets of ranks making a straight, weakest first.
+	Straights []string
+	// Classes are the kinds of hand from weakest to strongest. A hand is of
+	// the strongest class it matches.
+	Classes  []Class
+	TieBreak TieBreak
+}
+
+var camelClasses = []Class{
+	{Name: "high card"},
+	{Name: "one pair", Groups: []int{2}},
+	{Name: "two pair", Groups: []int{2, 2}},
+	{Name: "three of a kind", Groups: []int{3}},
+	{Name: "full house", Groups: []int{3, 2}},
+	{Name: "four of a kind", Groups: []int{4}},
+	{Name: "five of a kind", Groups: []int{5}},
+}
+
+// CamelCards are the rules of part 1.
+var CamelCards = Rules{
+	Name:     "camel cards",
+	Size:     5,
+	Ranks:    "23456789TJQKA",
+	Classes:  camelClasses,
+	TieBreak: InOrder,
+}
+
+// CamelCardsJokers are the rules of part 2, where J is the weakest card but
+// stands in for any other.
+var CamelCardsJokers = Rules{
+	Name:     "camel cards with jokers",
+	Size:     5,
+	Ranks:    "J23456789TQKA",
+	Wild:     "J",
+	Classes:  camelClasses,
+	TieBreak: InOrder,
+}
+
+// Poker are the rules of five card poker, with hands written like
+// "TH JH QH KH AH". An ace can also be low in the straight A2345.
+var Poker = Rules{
+	Name:      "poker",
+	Size:      5,
+	Ranks:     "23456789TJQKA",
+	Suits:     "CDHS",
+	Straights: append([]string{"A2345"}, windows("23456789TJQKA", 5)...),
+	Classes: []Class{
+		{Name: "high card"},
+		{Name: "one pair", Groups: []int{2}},
+		{Name: "two pair", Groups: []int{2, 2}},
+		{Name: "three of a kind", Groups: []int{3}},
+		{Name: "straight", Straight: true},
+		{Name: "flush", Flush: true},
+		{Name: "full house", Groups: []int{3, 2}},
+		{Name: "four of a kind", Groups: []int{4}},
+		{Name: "straight flush", Straight: true, Flush: true},
+	},
+	TieBreak: ByGroup,
+}
+
+// windows returns every run of n consecutive ranks, weakest first.
+func windows(ranks string, n int) []string {
+	runs := []string{}
+	for i := 0; i+n <= len(ranks); i++ {
+		runs = append(runs, ranks[i:i+n])
+	}
+	return runs
+}
+
+// ParseHand parses a hand written as one rune per card, or for games with
+// suits, as space separated rank and suit pairs.
+func (r Rules) ParseHand(s string) ([]Card, error) {
+	hand := []Card{}
+	if r.Suits == "" {
+		for _, rank := range s {
+			hand = append(hand, Card{Rank: rank})
+		}
+	} else {
+		for _, field := range strings.Fields(s) {
+			card := []rune(field)
+			if len(card) != 2 || !strings.ContainsRune(r.Suits, card[1]) {
+				return nil, fmt.Errorf("invalid %s card %q", r.Name, field)
+			}
+			hand = append(hand, Card{card[0], card[1]})
+		}
+	}
+
+	for _, card := range hand {
+		if !strings.ContainsRune(r.Ranks, card.Rank) {
+			return nil, fmt.Errorf("invalid %s card %q in %q", r.Name, card, s)
+		}
+	}
+	if len(hand) != r.Size {
+		return nil, fmt.Errorf("%s hand %q has %d cards, want %d", r.Name, s, len(hand), r.Size)
+	}
+	return hand, nil
+}
+
+// FormatHand writes a hand the way ParseHand reads it.
+func (r Rules) FormatHand(hand []Card) string {
+	cards := make([]string, len(hand))
+	for i, card := range hand {
+		cards[i] = card.String()
+	}
+	if r.Suits == "" {
+		return strings.Join(cards, "")
+	}
+	return strings.Join(cards, " ")
+}
+
+// Rating is how strong a hand is under some rules. Ratings order first by
+// Class, an index into the rules' classes, and then by Key.
+type Rating struct {
+	Class int
+	Key   []int
+}
+
+// CompareRatings orders ratings from weakest to strongest.
+func CompareRatings(a Rating, b Rating) int {
+	if c := cmp.Compare(a.Class, b.Class); c != 0 {
+		return c
+	}
+	return slices.Compare(a.Key, b.Key)
+}
+
+// Rate returns the rating of a hand parsed by ParseHand.
+func (r Rules) Rate(hand []Card) Rating {
+	class := r.classify(hand)
+	return Rating{class, r.key(hand, r.Classes[class])}
+}
+
+func (r Rules) rank(card Card) int {
+	return strings.IndexRune(r.Ranks, card.Rank)
+}
+
+func (r Rules) isWild(card Card) bool {
+	return strings.ContainsRune(r.Wild, card.Rank)
+}
+
+// classify returns the index of the strongest class the hand matches.
+func (r Rules) classify(hand []Card) int {
+	sizes, wild := r.groups(hand)
+	for i := len(r.Classes) - 1; i > 0; i-- {
+		if r.matches(hand, r.Classes[i], sizes, wild) {
+			return i
+		}
+	}
+	return 0
+}
+
+// matches reports whether the hand, whose groups of cards are sizes and wild
+// as returned by groups, is of the class.
+func (r Rules) matches(hand []Card, class Class, sizes []int, wild int) bool {
+	if !hasGroups(sizes, wild, class.Groups) {
+		return false
+	}
+	if class.Straight {
+		if _, ok := r.straight(hand); !ok {
+			return false
+		}
+	}
+	return !class.Flush || r.isFlush(hand)
+}
+
+// substitute returns the strongest class the hand can be made by replacing
+// its wildcards with other cards, along with the replaced hand. It tries
+// every replacement, so unlike classify it relies on no reasoning about
+// which replacement is best, and serves to check it.
+func (r Rules) substitute(hand []Card) (int, []Card) {
+	plain := r
+	plain.Wild = ""
+
+	wilds := []int{}
+	for i, card := range hand {
+		if r.isWild(card) {
+			wilds = append(wilds, i)
+		}
+	}
+
+	candidates := []Card{}
+	for _, rank := range r.Ranks {
+		if strings.ContainsRune(r.Wild, rank) {
+			continue
+		}
+		if r.Suits == "" {
+			candidates = append(candidates, Card{Rank: rank})
+		}
+		for _, suit := range r.Suits {
+			candidates = append(candidates, Card{rank, suit})
+		}
+	}
+
+	best, bestHand := -1, hand
+	substituted := slices.Clone(hand)
+	// Classes do not depend on the order of the cards, so the wildcards only
+	// need to try each combination of candidates once.
+	var try func(wild int, from int)
+	try = func(wild int, from int) {
+		if wild == len(wilds) {
+			if class := plain.classify(substituted); class > best {
+				best, bestHand = class, slices.Clone(substituted)
+			}
+			return
+		}
+		for i := from; i < len(candidates); i++ {
+			substituted[wilds[wild]] = candidates[i]
+			try(wild+1, i)
+		}
+	}
+	try(0, 0)
+
+	return best, bestHand
+}
+
+// groups returns the sizes of the groups of non-wild cards of equal rank,
+// largest first, along with the number of wildcards.
+func (r Rules) groups(hand []Card) (sizes []int, wild int) {
+	counts := make([]int, len(r.Ranks))
+	for _, card := range hand {
+		if r.isWild(card) {
+			wild += 1
+		} else {
+			counts[r.rank(card)] += 1
+		}
+	}
+
+	for _, count := range counts {
+		if count > 0 {
+			sizes = append(sizes, count)
+		}
+	}
+	slices.SortFunc(sizes, func(a, b int) int { return b - a })
+	return sizes, wild
+}
+
+// hasGroups reports whether the wildcards can fill out the hand's groups to
+// at least the given sizes. Filling the largest groups up to the largest
+// sizes needs the fewest wildcards, and any left over only grow a group.
+func hasGroups(sizes []int, wild int, want []int) bool {
+	needed := 0
+	for i, size := range want {
+		have := 0
+		if i < len(sizes) {
+			have = sizes[i]
+		}
+		needed += max(size-have, 0)
+	}
+	return needed <= wild
+}
+
+// straight returns the index of the strongest straight the wildcards can
+// complete the hand to.
+func (r Rules) straight(hand []Card) (int, bool) {
+	for i := len(r.Straights) - 1; i >= 0; i-- {
+		used := map[rune]bool{}
+		ok := true
+		for _, card := range hand {
+			if r.isWild(card) {
+				continue
+			}
+			if used[card.Rank] || !strings.ContainsRune(r.Straights[i], card.Rank) {
+				ok = false
+				break
+			}
+			used[card.Rank] = true
+		}
+		if ok {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (r Rules) isFlush(hand []Card) bool {
+	if r.Suits == "" {
+		return false
+	}
+
+	var suit rune
+	for _, card := range hand {
+		if r.isWild(card) {
+			continue
+		}
+		if suit != 0 && card.Suit != suit {
+			return false
+		}
+		suit = card.Suit
+	}
+	return true
+}
+
+// key returns what breaks ties between hands of the same class.
+func (r Rules) key(hand []Card, class Class) []int {
+	if r.TieBreak == InOrder {
+		key := make([]int, len(hand))
+		for i, card := range hand {
+			key[i] = r.rank(card)
+		}
+		return key
+	}
+
+	if class.Straight {
+		i, _ := r.straight(hand)
+		return []int{i}
+	}
+
+	counts := map[int]int{}
+	for _, card := range hand {
+		counts[r.rank(card)] += 1
+	}
+	key := []int{}
+	for rank := range counts {
+		key = append(key, rank)
+	}
+	slices.SortFunc(key, func(a, b int) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(b, a)
+	})
+	return key
+}
@@ -0,0 +1,158 @@
+package day07
+
+import (
+	"slices"
+	"testing"
+)
+
+func mustParseHand(t *testing.T, rules Rules, s string) []Card {
+	t.Helper()
+	hand, err := rules.ParseHand(s)
+	if err != nil {
+		t.Fatalf("ParseHand(%q) error = %v", s, err)
+	}
+	return hand
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		rules Rules
+		hand  string
+		want  string
+	}{
+		{CamelCards, "32T3K", "one pair"},
+		{CamelCards, "KTJJT", "two pair"},
+		{CamelCards, "T55J5", "three of a kind"},
+		{CamelCards, "23332", "full house"},
+		{CamelCardsJokers, "KTJJT", "four of a kind"},
+		{CamelCardsJokers, "2233J", "full house"},
+		{CamelCardsJokers, "JJJJJ", "five of a kind"},
+		{CamelCardsJokers, "2345J", "one pair"},
+		{Poker, "2H 3D 5S 9C KD", "high card"},
+		{Poker, "2C 3H 4S 8C AH", "high card"},
+		{Poker, "AH 2D 3C 4S 5S", "straight"},
+		{Poker, "TD JH QC KS AS", "straight"},
+		{Poker, "2H 7H 4H 8H KH", "flush"},
+		{Poker, "9S TS JS QS KS", "straight flush"},
+		{Poker, "2H 2D 2S KC KD", "full house"},
+		{Poker, "2H 2D 2S 2C KD", "four of a kind"},
+	}
+	for _, tt := range tests {
+		hand := mustParseHand(t, tt.rules, tt.hand)
+		if got := tt.rules.Classes[tt.rules.classify(hand)].Name; got != tt.want {
+			t.Errorf("%s classify(%q) = %v, want %v", tt.rules.Name, tt.hand, got, tt.want)
+		}
+	}
+}
+
+func TestPokerRanking(t *testing.T) {
+	// Weakest to strongest.
+	hands := []string{
+		"2H 3D 5S 9C KD",
+		"2C 3H 4S 8C AH",
+		"2H 2D 5S 9C KD",
+		"3H 3D 2S 9C KD",
+		"3H 3D 2S 2C KD",
+		"3H 3D 4S 4C 2D",
+		"7H 7D 7S 2C 3D",
+		"AH 2D 3C 4S 5S",
+		"2H 3D 4C 5S 6S",
+		"TD JH QC KS AS",
+		"2H 7H 4H 8H KH",
+		"2H 2D 2S KC KD",
+		"3H 3D 3S 2C 2D",
+		"2H 2D 2S 2C KD",
+		"AH 2H 3H 4H 5H",
+		"9S TS JS QS KS",
+	}
+
+	ratings := []Rating{}
+	for _, s := range hands {
+		ratings = append(ratings, Poker.Rate(mustParseHand(t, Poker, s)))
+	}
+	for i := 1; i < len(ratings); i++ {
+		if CompareRatings(ratings[i-1], ratings[i]) >= 0 {
+			t.Errorf("%q does not rank below %q", hands[i-1], hands[i])
+		}
+	}
+
+	sorted := slices.Clone(ratings)
+	slices.Reverse(sorted)
+	slices.SortFunc(sorted, CompareRatings)
+	if !slices.EqualFunc(sorted, ratings, func(a, b Rating) bool { return CompareRatings(a, b) == 0 }) {
+		t.Errorf("sorting reversed hands = %v, want %v", sorted, ratings)
+	}
+}
+
+func TestParseHand(t *testing.T) {
+	tests := []struct {
+		rules Rules
+		hand  string
+	}{
+		{CamelCards, "32T3"},
+		{CamelCards, "32T3X"},
+		{Poker, "2H 3D 5S 9C"},
+		{Poker, "2H 3D 5S 9C KX"},
+		{Poker, "2H 3D 5S 9C 1D"},
+	}
+	for _, tt := range tests {
+		if _, err := tt.rules.ParseHand(tt.hand); err == nil {
+			t.Errorf("%s ParseHand(%q) succeeded", tt.rules.Name, tt.hand)
+		}
+	}
+
+	hand := mustParseHand(t, Poker, "TH JH QH KH AH")
+	if got := Poker.FormatHand(hand); got != "TH JH QH KH AH" {
+		t.Errorf("FormatHand() = %q", got)
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	hand := mustParseHand(t, CamelCardsJokers, "KTJJT")
+	class, substituted := CamelCardsJokers.substitute(hand)
+	if got := CamelCardsJokers.Classes[class].Name; got != "four of a kind" {
+		t.Errorf("substitute(KTJJT) class = %v, want four of a kind", got)
+	}
+	if got := CamelCardsJokers.FormatHand(substituted); got != "KTTTT" {
+		t.Errorf("substitute(KTJJT) = %v, want KTTTT", got)
+	}
+	if got := CamelCardsJokers.FormatHand(hand); got != "KTJJT" {
+		t.Errorf("substitute() modified hand to %v", got)
+	}
+}
+
+// TestClassifyWithJokers checks classify against trying every joker
+// substitution, for all 13^5 hands.
+func TestClassifyWithJokers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("enumerates every hand")
+	}
+
+	ranks := []rune(CamelCardsJokers.Ranks)
+	cards := make([]rune, 5)
+	disagreements := 0
+	var enumerate func(i int)
+	enumerate = func(i int) {
+		if i == len(cards) {
+			hand := mustParseHand(t, CamelCardsJokers, string(cards))
+			want, substituted := CamelCardsJokers.substitute(hand)
+			if got := CamelCardsJokers.classify(hand); got != want {
+				disagreements += 1
+				if disagreements <= 20 {
+					t.Errorf("classify(%q) = %v, want %v as %s",
+						string(cards), got, want, CamelCardsJokers.FormatHand(substituted))
+				}
+			}
+			return
+		}
+		for _, rank := range ranks {
+			cards[i] = rank
+			enumerate(i + 1)
+		}
+	}
+	enumerate(0)
+
+	if disagreements > 0 {
+		t.Errorf("%d of %d hands disagree", disagreements, 13*13*13*13*13)
+	}
+}
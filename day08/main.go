@@ -1,14 +1,15 @@
-package main
+package day08
 
 import (
 	_ "embed"
-	"flag"
 	"fmt"
 	"math/big"
 	"regexp"
 	"strings"
 
-	"github.com/basokant/advent-of-code-2023/util"
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/util/graph"
+	"github.com/basokant/advent-of-code-2023/util/numtheory"
 	"github.com/samber/lo"
 )
 
@@ -16,136 +17,220 @@ import (
 var input string
 
 func init() {
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	aoc.Register(aoc.Day{
+		Number:   8,
+		Input:    input,
+		Examples: examples,
+		New:      func() aoc.Solver { return &solver{} },
+	})
+}
+
+// solver parses the network once, so that a node missing from it is reported
+// as an error rather than a walk that goes nowhere.
+type solver struct {
+	instructions string
+	network      *Network
+}
+
+func (s *solver) Parse(input string) (err error) {
+	s.instructions, s.network, err = parseInput(input)
+	return err
 }
 
-func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
+func (s *solver) Part1() (aoc.Answer, error) {
+	numSteps, err := part1(s.instructions, s.network)
+	if err != nil {
+		return aoc.Answer{}, err
 	}
+	return aoc.Int(numSteps), nil
 }
 
-func part1(input string) int {
-	instructions, nodeMap := parseInput(input, false)
-	return numStepsToZZZ("AAA", nodeMap, instructions)
+func (s *solver) Part2() (aoc.Answer, error) {
+	steps, err := part2(s.instructions, s.network)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Big(steps), nil
 }
 
-func part2(input string) int64 {
-	instructions, nodeMap := parseInput(input, false)
+func part1(instructions string, network *Network) (int, error) {
+	if _, ok := network.Vertex("AAA"); !ok {
+		return 0, fmt.Errorf("no node AAA to start from")
+	}
+	numSteps, ok := numStepsToZZZ("AAA", network, instructions)
+	if !ok {
+		return 0, fmt.Errorf("the walk from AAA loops without reaching ZZZ")
+	}
+	return numSteps, nil
+}
 
-	nodes := lo.Filter(lo.Keys(nodeMap), func(node string, _ int) bool {
-		return node[2] == 'A'
+func part2(instructions string, network *Network) (*big.Int, error) {
+	nodes := lo.FilterMap(network.Vertices(), func(vertex *graph.Vertex[string, struct{}], _ int) (string, bool) {
+		return vertex.Key, strings.HasSuffix(vertex.Key, "A")
 	})
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no node ending in A to start from")
+	}
 
-	numStepsToZ := lo.Map(nodes, func(node string, _ int) *big.Int {
-		return numStepsToZ(node, nodeMap, instructions)
+	cycles := lo.Map(nodes, func(node string, _ int) graph.Cycle {
+		return ghostCycle(node, network, instructions)
 	})
 
-	return LCM(numStepsToZ...).Int64()
-}
+	// The puzzle inputs are built so that every ghost reaches a Z node exactly
+	// at the end of each of its cycles, where the LCM of the cycle lengths is
+	// the answer. Anything else needs the general combination.
+	if lo.EveryBy(cycles, isAligned) {
+		cycleLengths := lo.Map(cycles, func(cycle graph.Cycle, _ int) *big.Int {
+			return big.NewInt(int64(cycle.Length))
+		})
+		return numtheory.LCM(cycleLengths...), nil
+	}
 
-type Pair[T any] struct {
-	left  T
-	right T
+	steps, ok := earliestCommonHit(cycles)
+	if !ok {
+		return nil, fmt.Errorf("the walks from the %d nodes ending in A are never all on a Z node at once", len(nodes))
+	}
+	return steps, nil
 }
 
-func parseInput(input string, _ bool) (string, map[string]Pair[string]) {
-	re := regexp.MustCompile(`\b\w+\b`)
+// Network is the map of nodes, where each node has an edge to its left node
+// followed by an edge to its right node.
+type Network = graph.Graph[string, struct{}]
 
-	lines := strings.Split(input, "\n")
-	instructions := lines[0]
+var nodePattern = regexp.MustCompile(`^(\w+) = \((\w+), (\w+)\)$`)
 
-	nodeMap := make(map[string]Pair[string])
-	for _, line := range lines[2:] {
-		matches := re.FindAllString(line, -1)
-		node, left, right := matches[0], matches[1], matches[2]
-		nodeMap[node] = Pair[string]{left, right}
+func parseInput(input string) (string, *Network, error) {
+	instructions, nodes, ok := strings.Cut(input, "\n\n")
+	if !ok {
+		return "", nil, fmt.Errorf("want instructions and nodes separated by a blank line")
+	}
+	if instructions == "" || strings.Trim(instructions, "LR") != "" {
+		return "", nil, fmt.Errorf("line 1: want instructions of only L and R, got %q", instructions)
 	}
 
-	return instructions, nodeMap
-}
+	network := graph.NewDirected[string, struct{}]()
+	lines := strings.Split(nodes, "\n")
+	edges := make([][]string, len(lines))
+	for i, line := range lines {
+		edges[i] = nodePattern.FindStringSubmatch(line)
+		if edges[i] == nil {
+			return "", nil, fmt.Errorf("line %d: want \"AAA = (BBB, CCC)\", got %q", i+3, line)
+		}
+		if _, ok := network.Vertex(edges[i][1]); ok {
+			return "", nil, fmt.Errorf("line %d: node %s defined twice", i+3, edges[i][1])
+		}
+		network.AddVertex(edges[i][1], struct{}{})
+	}
 
-func numStepsToZZZ(node string, nodeMap map[string]Pair[string], instructions string) int {
-	next := 0
-	numSteps := 0
-	for node != "ZZZ" {
-		step := instructions[next]
-		switch step {
-		case 'L':
-			node = nodeMap[node].left
-		case 'R':
-			node = nodeMap[node].right
+	for i, matches := range edges {
+		node, left, right := matches[1], matches[2], matches[3]
+		if err := network.AddEdge(node, left); err != nil {
+			return "", nil, fmt.Errorf("line %d: %w", i+3, err)
+		}
+		if err := network.AddEdge(node, right); err != nil {
+			return "", nil, fmt.Errorf("line %d: %w", i+3, err)
 		}
+	}
+
+	return instructions, network, nil
+}
 
-		next = (next + 1) % len(instructions)
-		numSteps += 1
+func step(network *Network, node string, instruction byte) string {
+	next := network.Neighbors(node)
+	if instruction == 'L' {
+		return next[0].Key
 	}
+	return next[1].Key
+}
 
-	return numSteps
+// position is a state of the walk: the current node and the index of the next
+// instruction. Each position has exactly one successor.
+type position struct {
+	node string
+	next int
 }
 
-func numStepsToZ(node string, nodeMap map[string]Pair[string], instructions string) *big.Int {
-	next := 0
-	var numSteps int64 = 0
-
-	for node[2] != 'Z' {
-		step := instructions[next]
-		switch step {
-		case 'L':
-			node = nodeMap[node].left
-		case 'R':
-			node = nodeMap[node].right
+// numStepsTo walks the network from node following the instructions, and
+// returns the number of steps taken to reach a node satisfying isEnd, or false
+// if the walk loops without reaching one.
+func numStepsTo(node string, network *Network, instructions string, isEnd func(string) bool) (int, bool) {
+	walk := func(p position) []position {
+		node := step(network, p.node, instructions[p.next])
+		return []position{{node, (p.next + 1) % len(instructions)}}
+	}
+
+	numSteps, found := 0, false
+	graph.BFS(position{node, 0}, walk, func(p position, depth int) bool {
+		if isEnd(p.node) {
+			numSteps, found = depth, true
+			return false
 		}
+		return true
+	})
 
-		next = (next + 1) % len(instructions)
-		numSteps += 1
-	}
-	return big.NewInt(numSteps)
+	return numSteps, found
 }
 
-// gcd calculates the Greatest Common Divisor using Euclid's algorithm
-func gcd(a, b *big.Int) *big.Int {
-	for b.Cmp(big.NewInt(0)) != 0 {
-		temp := new(big.Int)
-		temp.Set(b)
-		b.Mod(a, b)
-		a.Set(temp)
-	}
-	return a
+func numStepsToZZZ(node string, network *Network, instructions string) (int, bool) {
+	return numStepsTo(node, network, instructions, func(node string) bool {
+		return node == "ZZZ"
+	})
 }
 
-// lcm calculates the Least Common Multiple using the formula: LCM(a, b) = |a * b| / GCD(a, b)
-func lcm(a, b *big.Int) *big.Int {
-	product := new(big.Int).Mul(a, b)
-	gcdVal := gcd(a, b)
-	if gcdVal.Cmp(big.NewInt(0)) == 0 {
-		return big.NewInt(0)
+// ghostCycle finds where the walk from node starts repeating and the steps at
+// which it is on a Z node.
+func ghostCycle(node string, network *Network, instructions string) graph.Cycle {
+	walk := func(p position) position {
+		node := step(network, p.node, instructions[p.next])
+		return position{node, (p.next + 1) % len(instructions)}
 	}
-	return product.Div(product, gcdVal)
+
+	return graph.FindCycle(position{node, 0}, walk, func(p position) bool {
+		return strings.HasSuffix(p.node, "Z")
+	})
 }
 
-func LCM(nums ...*big.Int) *big.Int {
-	if len(nums) == 0 {
-		return big.NewInt(0)
+// isAligned reports whether the walk is on a Z node at exactly the multiples
+// of its cycle length. A hit before the walk enters its cycle never recurs,
+// so it does not count.
+func isAligned(cycle graph.Cycle) bool {
+	return len(cycle.Hits) == 1 && cycle.Hits[0] == cycle.Length && cycle.Hits[0] >= cycle.Prefix
+}
+
+// earliestCommonHit returns the first step at which every walk is on a Z node.
+// Steps before every walk has entered its cycle are checked one by one. After
+// that each walk is on a Z node at offset + k*length for one of its cycle hits,
+// so every choice of hits is a system of congruences solved with the CRT.
+func earliestCommonHit(cycles []graph.Cycle) (*big.Int, bool) {
+	maxPrefix := lo.Max(lo.Map(cycles, func(cycle graph.Cycle, _ int) int {
+		return cycle.Prefix
+	}))
+
+	for steps := 0; steps < maxPrefix; steps++ {
+		if lo.EveryBy(cycles, func(cycle graph.Cycle) bool { return cycle.IsHit(steps) }) {
+			return big.NewInt(int64(steps)), true
+		}
 	}
 
-	result := nums[0]
-	for i := 1; i < len(nums); i++ {
-		result = lcm(result, nums[i])
+	var earliest *big.Int
+	var search func(i int, system numtheory.Congruence)
+	search = func(i int, system numtheory.Congruence) {
+		if i == len(cycles) {
+			steps := system.AtLeast(big.NewInt(int64(maxPrefix)))
+			if earliest == nil || steps.Cmp(earliest) < 0 {
+				earliest = steps
+			}
+			return
+		}
+
+		for _, hit := range cycles[i].CycleHits() {
+			hits := numtheory.NewCongruence(int64(hit), int64(cycles[i].Length))
+			if combined, ok := numtheory.CRT(system, hits); ok {
+				search(i+1, combined)
+			}
+		}
 	}
-	return result
+	search(0, numtheory.NewCongruence(0, 1))
+
+	return earliest, earliest != nil
 }
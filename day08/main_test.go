@@ -1,72 +1,128 @@
-package main
+package day08
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc/aoctest"
 )
 
 func TestPart1(t *testing.T) {
+	aoctest.Examples(t, 8, 1)
+}
+
+func TestPart2(t *testing.T) {
+	aoctest.Examples(t, 8, 2)
+}
+
+func TestParseInputErrors(t *testing.T) {
 	tests := []struct {
 		name  string
 		input string
-		want  int
+		want  string
 	}{
 		{
-			name: "test1",
-			input: `RL
-
-AAA = (BBB, CCC)
-BBB = (DDD, EEE)
-CCC = (ZZZ, GGG)
-DDD = (DDD, DDD)
-EEE = (EEE, EEE)
-GGG = (GGG, GGG)
-ZZZ = (ZZZ, ZZZ)`,
-			want: 2,
+			name:  "no nodes",
+			input: "LR",
+			want:  "blank line",
 		},
 		{
-			name: "test2",
-			input: `LLR
-
-AAA = (BBB, BBB)
-BBB = (AAA, ZZZ)
-ZZZ = (ZZZ, ZZZ)`,
-			want: 6,
+			name:  "bad instruction",
+			input: "LRX\n\nAAA = (AAA, AAA)",
+			want:  `line 1: want instructions of only L and R, got "LRX"`,
+		},
+		{
+			name:  "bad node",
+			input: "LR\n\nAAA = (AAA, AAA)\nBBB = AAA",
+			want:  `line 4: want "AAA = (BBB, CCC)"`,
+		},
+		{
+			name:  "duplicate node",
+			input: "LR\n\nAAA = (AAA, AAA)\nAAA = (AAA, AAA)",
+			want:  "line 4: node AAA defined twice",
+		},
+		{
+			name:  "missing node",
+			input: "LR\n\nAAA = (BBB, ZZZ)\nBBB = (AAA, AAA)",
+			want:  "line 3: vertex ZZZ not in graph",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := part1(tt.input); got != tt.want {
-				t.Errorf("part1() = %v, want %v", got, tt.want)
+			_, _, err := parseInput(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseInput() error = %v, want %q", err, tt.want)
 			}
 		})
 	}
 }
 
-func TestPart2(t *testing.T) {
+func TestPart1Unreachable(t *testing.T) {
 	tests := []struct {
 		name  string
 		input string
-		want  int64
+		want  string
 	}{
 		{
-			name: "test1",
-			input: `LR
+			name:  "loop without ZZZ",
+			input: "L\n\nAAA = (BBB, ZZZ)\nBBB = (AAA, ZZZ)\nZZZ = (ZZZ, ZZZ)",
+			want:  "loops without reaching ZZZ",
+		},
+		{
+			name:  "no start",
+			input: "L\n\nBBB = (ZZZ, ZZZ)\nZZZ = (ZZZ, ZZZ)",
+			want:  "no node AAA",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instructions, network, err := parseInput(tt.input)
+			if err != nil {
+				t.Fatalf("parseInput() error = %v", err)
+			}
+			if got, err := part1(instructions, network); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("part1() = %v, %v, want error %q", got, err, tt.want)
+			}
+		})
+	}
+}
 
-11A = (11B, XXX)
-11B = (XXX, 11Z)
-11Z = (11B, XXX)
-22A = (22B, XXX)
-22B = (22C, 22C)
-22C = (22Z, 22Z)
-22Z = (22B, 22B)
-XXX = (XXX, XXX)`,
-			want: 6,
+func TestPart2NoCommonHit(t *testing.T) {
+	// The walk from 11A is on a Z node at odd steps and the walk from 22A at
+	// even steps.
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "hits never line up",
+			input: "L\n\n11A = (11Z, 11Z)\n11Z = (11A, 11A)\n22A = (22B, 22B)\n22B = (22Z, 22Z)\n22Z = (22B, 22B)",
+			want:  "never all on a Z node",
+		},
+		{
+			// 11Z is at step 3, the cycle length, but before the walk from
+			// 11A enters its cycle at step 5, so it is never hit again.
+			name: "hit only before the cycle",
+			input: "L\n\n11A = (11B, 11B)\n11B = (11C, 11C)\n11C = (11Z, 11Z)\n11Z = (11D, 11D)\n" +
+				"11D = (11E, 11E)\n11E = (11F, 11F)\n11F = (11G, 11G)\n11G = (11E, 11E)\n" +
+				"22A = (22B, 22B)\n22B = (22Z, 22Z)\n22Z = (22B, 22B)",
+			want: "never all on a Z node",
+		},
+		{
+			name:  "no start",
+			input: "L\n\nZZZ = (ZZZ, ZZZ)",
+			want:  "no node ending in A",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := part2(tt.input); got != tt.want {
-				t.Errorf("part2() = %v, want %v", got, tt.want)
+			instructions, network, err := parseInput(tt.input)
+			if err != nil {
+				t.Fatalf("parseInput() error = %v", err)
+			}
+			if got, err := part2(instructions, network); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("part2() = %v, %v, want error %q", got, err, tt.want)
 			}
 		})
 	}
@@ -1,125 +1,96 @@
-package main
+package day09
 
 import (
 	_ "embed"
-	"flag"
 	"fmt"
-	"slices"
+	"math/big"
 	"strconv"
 	"strings"
 
-	"github.com/basokant/advent-of-code-2023/util"
-	"github.com/samber/lo"
+	"github.com/basokant/advent-of-code-2023/aoc"
 )
 
 //go:embed input.txt
 var input string
 
 func init() {
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
-}
-
-func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-}
-
-func part1(input string) int {
-	histories := parseInput(input, false)
-
-	extrapolatedValues := lo.Map(histories, func(history []int, _ int) int {
-		val, _ := extrapolateRight(history)
-		return val
+	aoc.Register(aoc.Day{
+		Number:   9,
+		Input:    input,
+		Examples: examples,
+		New:      func() aoc.Solver { return &solver{} },
 	})
-
-	return lo.Sum(extrapolatedValues)
 }
 
-func part2(input string) int {
-	histories := parseInput(input, false)
-
-	extrapolatedValues := lo.Map(histories, func(history []int, _ int) int {
-		val, _ := extrapolateLeft(history)
-		return val
-	})
-
-	return lo.Sum(extrapolatedValues)
+// solver reports histories that cannot be parsed or extrapolated as errors
+// rather than counting them as zero.
+type solver struct {
+	histories [][]int
 }
 
-func parseInput(input string, _ bool) [][]int {
-	lines := strings.Split(input, "\n")
-
-	histories := lo.Map(lines, func(line string, _ int) []int {
-		historyInput := strings.Split(line, " ")
-		return lo.Map(historyInput, func(numStr string, _ int) int {
-			num, _ := strconv.Atoi(numStr)
-			return num
-		})
-	})
+func (s *solver) Parse(input string) (err error) {
+	s.histories, err = parseInput(input)
+	return err
+}
 
-	return histories
+func (s *solver) Part1() (aoc.Answer, error) {
+	sum, err := part1(s.histories)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Big(sum), nil
 }
 
-func computeDifferences(differences [][]int) ([][]int, error) {
-	lastDifferences, err := lo.Last(differences)
+func (s *solver) Part2() (aoc.Answer, error) {
+	sum, err := part2(s.histories)
 	if err != nil {
-		return nil, err
-	} else if slices.Equal(lo.Uniq(lastDifferences), []int{0}) {
-		return differences, nil
+		return aoc.Answer{}, err
 	}
+	return aoc.Big(sum), nil
+}
 
-	newDifferences := lo.RepeatBy(len(lastDifferences)-1, func(i int) int {
-		return lastDifferences[i+1] - lastDifferences[i]
+func part1(histories [][]int) (*big.Int, error) {
+	return sumExtrapolated(histories, func(history []int) int64 {
+		return int64(len(history))
 	})
-
-	differences = append(differences, newDifferences)
-	return computeDifferences(differences)
 }
 
-func extrapolateRight(history []int) (int, error) {
-	allDifferences, err := computeDifferences([][]int{history})
-	if err != nil {
-		return 0, err
-	}
+func part2(histories [][]int) (*big.Int, error) {
+	return sumExtrapolated(histories, func(history []int) int64 {
+		return -1
+	})
+}
 
-	lastDifference := 0
-	allDifferences = lo.Reverse(allDifferences)
-	for _, differences := range allDifferences {
-		lastVal, err := lo.Last(differences)
+// sumExtrapolated sums the value of each history at the index returned by at.
+func sumExtrapolated(histories [][]int, at func(history []int) int64) (*big.Int, error) {
+	sum := new(big.Rat)
+	for i, history := range histories {
+		p, err := Fit(history)
 		if err != nil {
-			return 0, err
+			return nil, fmt.Errorf("history %d: %w", i+1, err)
 		}
-		lastDifference = lastVal + lastDifference
+		sum.Add(sum, p.At(big.NewInt(at(history))))
 	}
-	return lastDifference, nil
-}
 
-func extrapolateLeft(history []int) (int, error) {
-	allDifferences, err := computeDifferences([][]int{history})
-	if err != nil {
-		return 0, err
+	if !sum.IsInt() {
+		return nil, fmt.Errorf("extrapolated values sum to %v, not an integer", sum)
 	}
+	return sum.Num(), nil
+}
 
-	lastDifference := 0
-	allDifferences = lo.Reverse(allDifferences)
-	for _, differences := range allDifferences {
-		firstVal := differences[0]
-		lastDifference = firstVal - lastDifference
+func parseInput(input string) ([][]int, error) {
+	lines := strings.Split(input, "\n")
+
+	histories := make([][]int, len(lines))
+	for i, line := range lines {
+		for _, field := range strings.Fields(line) {
+			num, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid number %q", i+1, field)
+			}
+			histories[i] = append(histories[i], num)
+		}
 	}
-	return lastDifference, nil
+
+	return histories, nil
 }
@@ -1,51 +1,48 @@
-package main
+package day09
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+	"github.com/basokant/advent-of-code-2023/aoc/aoctest"
 )
 
+func mustParse(t *testing.T, input string) [][]int {
+	t.Helper()
+	histories, err := parseInput(input)
+	if err != nil {
+		t.Fatalf("parseInput() error = %v", err)
+	}
+	return histories
+}
+
 func TestPart1(t *testing.T) {
-	tests := []struct {
-		name  string
-		input string
-		want  int
-	}{
-		{
-			name: "test1",
-			input: `0 3 6 9 12 15
-1 3 6 10 15 21
-10 13 16 21 30 45`,
-			want: 114,
-		},
+	aoctest.Examples(t, 9, 1)
+}
+
+func TestPart2(t *testing.T) {
+	aoctest.Examples(t, 9, 2)
+}
+
+func TestPartsReportErrors(t *testing.T) {
+	if _, err := parseInput("1 2 x"); err == nil {
+		t.Errorf("parseInput() error = nil, want invalid number")
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := part1(tt.input); got != tt.want {
-				t.Errorf("part1() = %v, want %v", got, tt.want)
-			}
-		})
+	if _, err := part1(mustParse(t, "0 3 6 9 12 15\n1 2 4")); err == nil {
+		t.Errorf("part1() error = nil, want history 2 not polynomial")
 	}
 }
 
-func TestPart2(t *testing.T) {
-	tests := []struct {
-		name  string
-		input string
-		want  int
-	}{
-		{
-			name: "test1",
-			input: `0 3 6 9 12 15
-1 3 6 10 15 21
-10 13 16 21 30 45`,
-			want: 2,
-		},
+func TestSolveReportsNotPolynomial(t *testing.T) {
+	day, err := aoc.Lookup(9)
+	if err != nil {
+		t.Fatal(err)
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := part2(tt.input); got != tt.want {
-				t.Errorf("part2() = %v, want %v", got, tt.want)
-			}
-		})
+	want := "history 1: [1 2 4 8 16 32] is not polynomial within its 6 values"
+	for part := 1; part <= 2; part++ {
+		if got, err := day.Solve(part, "1 2 4 8 16 32"); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Solve(%d) = %v, %v, want error %q", part, got, err, want)
+		}
 	}
 }
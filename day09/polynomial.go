@@ -0,0 +1,76 @@
+package day09
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Polynomial is the polynomial of least degree through a sequence of values
+// at 0, 1, 2, .... It is kept in Newton's forward difference form,
+//
+//	p(x) = Σ Δᵏp(0) · C(x, k)
+//
+// where Δᵏp(0) is the first value of the kth row of differences, so it can be
+// evaluated exactly at any index, including far past the end of the sequence
+// or before its start.
+type Polynomial struct {
+	differences []*big.Rat
+}
+
+// Fit returns the polynomial through values. A sequence of n values is only
+// known to be polynomial if some row of its differences is all zeros, so its
+// degree can be at most n-2: otherwise any n values would fit a polynomial of
+// degree n-1 and extrapolating from it would be a guess.
+func Fit(values []int) (Polynomial, error) {
+	row := make([]*big.Rat, len(values))
+	for i, v := range values {
+		row[i] = big.NewRat(int64(v), 1)
+	}
+
+	p := Polynomial{}
+	for len(row) > 0 {
+		if isZero(row) {
+			return p, nil
+		}
+		p.differences = append(p.differences, row[0])
+
+		next := make([]*big.Rat, len(row)-1)
+		for i := range next {
+			next[i] = new(big.Rat).Sub(row[i+1], row[i])
+		}
+		row = next
+	}
+
+	return Polynomial{}, fmt.Errorf("%v is not polynomial within its %d values", values, len(values))
+}
+
+func isZero(row []*big.Rat) bool {
+	for _, v := range row {
+		if v.Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Degree returns the degree of the polynomial, or -1 if it is zero
+// everywhere.
+func (p Polynomial) Degree() int {
+	return len(p.differences) - 1
+}
+
+// At returns the value of the polynomial at index x.
+func (p Polynomial) At(x *big.Int) *big.Rat {
+	sum := new(big.Rat)
+	// binomial is C(x, k), built up as C(x, k) = C(x, k-1) · (x-k+1) / k so
+	// that it is also defined for negative x.
+	binomial := big.NewRat(1, 1)
+	for k, difference := range p.differences {
+		if k > 0 {
+			factor := new(big.Rat).SetFrac(new(big.Int).Sub(x, big.NewInt(int64(k-1))), big.NewInt(int64(k)))
+			binomial.Mul(binomial, factor)
+		}
+		sum.Add(sum, new(big.Rat).Mul(difference, binomial))
+	}
+	return sum
+}
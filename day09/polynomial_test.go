@@ -0,0 +1,71 @@
+package day09
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		degree int
+	}{
+		{"zero", []int{0, 0, 0}, -1},
+		{"constant", []int{7, 7}, 0},
+		{"linear", []int{0, 3, 6, 9, 12, 15}, 1},
+		{"quadratic", []int{1, 3, 6, 10, 15, 21}, 2},
+		{"cubic", []int{10, 13, 16, 21, 30, 45}, 3},
+		{"negative", []int{-2, -8, -18, -32}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Fit(tt.values)
+			if err != nil {
+				t.Fatalf("Fit() error = %v", err)
+			}
+			if got := p.Degree(); got != tt.degree {
+				t.Errorf("Degree() = %v, want %v", got, tt.degree)
+			}
+			for i, want := range tt.values {
+				if got := p.At(big.NewInt(int64(i))); got.Cmp(big.NewRat(int64(want), 1)) != 0 {
+					t.Errorf("At(%d) = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFitNotPolynomial(t *testing.T) {
+	for _, values := range [][]int{{}, {5}, {1, 2}, {1, 2, 4}, {1, 2, 4, 8, 16, 32}} {
+		if p, err := Fit(values); err == nil {
+			t.Errorf("Fit(%v) = degree %d, want error", values, p.Degree())
+		}
+	}
+}
+
+func TestAt(t *testing.T) {
+	// n(n+1)/2 from its first values.
+	p, err := Fit([]int{0, 1, 3, 6, 10})
+	if err != nil {
+		t.Fatalf("Fit() error = %v", err)
+	}
+
+	tests := []struct {
+		x    string
+		want string
+	}{
+		{"5", "15"},
+		{"-1", "0"},
+		{"-5", "10"},
+		{"1000000000000", "500000000000500000000000"},
+		{"-1000000000000", "499999999999500000000000"},
+	}
+	for _, tt := range tests {
+		x, _ := new(big.Int).SetString(tt.x, 10)
+		want, _ := new(big.Rat).SetString(tt.want)
+		if got := p.At(x); got.Cmp(want) != 0 {
+			t.Errorf("At(%v) = %v, want %v", tt.x, got, want)
+		}
+	}
+}
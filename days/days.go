@@ -0,0 +1,15 @@
+// Package days imports every day so that its solvers are registered with
+// package aoc. New days only need to be added to this import list.
+package days
+
+import (
+	_ "github.com/basokant/advent-of-code-2023/day01"
+	_ "github.com/basokant/advent-of-code-2023/day02"
+	_ "github.com/basokant/advent-of-code-2023/day03"
+	_ "github.com/basokant/advent-of-code-2023/day04"
+	_ "github.com/basokant/advent-of-code-2023/day05"
+	_ "github.com/basokant/advent-of-code-2023/day06"
+	_ "github.com/basokant/advent-of-code-2023/day07"
+	_ "github.com/basokant/advent-of-code-2023/day08"
+	_ "github.com/basokant/advent-of-code-2023/day09"
+)
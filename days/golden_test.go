@@ -0,0 +1,42 @@
+package days
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/basokant/advent-of-code-2023/aoc"
+)
+
+// TestGolden runs every registered solver against its real input and checks
+// the answers accepted by the site, recorded in answers.json.
+func TestGolden(t *testing.T) {
+	golden, err := aoc.LoadGolden("../answers.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, day := range aoc.Days() {
+		for part := 1; part <= 2; part++ {
+			day, part := day, part
+			t.Run(fmt.Sprintf("day%02d/part%d", day.Number, part), func(t *testing.T) {
+				want, ok := golden.Answer(day.Number, part)
+				if !ok {
+					t.Skip("answer not known yet")
+				}
+				t.Parallel()
+
+				input, err := aoc.Input{}.Read(day, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := day.Solve(part, input)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !got.Equal(want) {
+					t.Errorf("got %v, want %v", got, want)
+				}
+			})
+		}
+	}
+}
@@ -2,10 +2,12 @@ module github.com/basokant/advent-of-code-2023
 
 go 1.21
 
-require github.com/samber/lo v1.39.0
+require (
+	github.com/deckarep/golang-set/v2 v2.5.0
+	github.com/samber/lo v1.39.0
+)
 
 require (
-	github.com/deckarep/golang-set/v2 v2.5.0 // indirect
 	github.com/spf13/cast v1.6.0 // indirect
 	golang.org/x/exp v0.0.0-20220303212507-bbda1eaf7a17 // indirect
 )
@@ -1,24 +1,11 @@
 package util
 
-import (
-	"bytes"
-	"fmt"
-	"os/exec"
-)
-
-// CopyToClipboard is for macOS
+// CopyToClipboard copies text with the backend named by AOC_CLIPBOARD, or the
+// first one detected. When there is none it warns and copies nothing.
 func CopyToClipboard(text string) error {
-	command := exec.Command("pbcopy")
-	command.Stdin = bytes.NewReader([]byte(text))
-
-	if err := command.Start(); err != nil {
-		return fmt.Errorf("error starting pbcopy command: %w", err)
-	}
-
-	err := command.Wait()
+	clipboard, err := NewClipboard("")
 	if err != nil {
-		return fmt.Errorf("error running pbcopy %w", err)
+		return err
 	}
-
-	return nil
+	return clipboard.Copy(text)
 }
@@ -0,0 +1,197 @@
+package util
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ClipboardEnv is the environment variable that selects a clipboard backend
+// by name, overriding detection.
+const ClipboardEnv = "AOC_CLIPBOARD"
+
+var ErrNoClipboard = errors.New("no clipboard backend available: install wl-copy, xclip or xsel, " +
+	"or choose osc52, file:PATH or none with -clipboard or " + ClipboardEnv)
+
+type Clipboard interface {
+	Name() string
+	Copy(text string) error
+}
+
+// commandClipboard pipes the text into an external program such as pbcopy.
+type commandClipboard struct {
+	name string
+	args []string
+	// display is the environment variable that must be set for the program
+	// to reach a running display server, if any.
+	display string
+}
+
+func (c commandClipboard) Name() string {
+	return c.name
+}
+
+func (c commandClipboard) Copy(text string) error {
+	command := exec.Command(c.name, c.args...)
+	command.Stdin = strings.NewReader(text)
+
+	var stderr bytes.Buffer
+	command.Stderr = &stderr
+
+	if err := command.Run(); err != nil {
+		return fmt.Errorf("error running %s: %w %s", c.name, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+var commandClipboards = []commandClipboard{
+	{name: "pbcopy"},
+	{name: "wl-copy", display: "WAYLAND_DISPLAY"},
+	{name: "xclip", args: []string{"-selection", "clipboard"}, display: "DISPLAY"},
+	{name: "xsel", args: []string{"--clipboard", "--input"}, display: "DISPLAY"},
+	{name: "clip.exe"},
+}
+
+// OSC52Clipboard asks the terminal to set the clipboard with an OSC 52
+// escape sequence, which also works over SSH in most modern terminals.
+type OSC52Clipboard struct {
+	Writer io.Writer
+}
+
+func (c OSC52Clipboard) Name() string {
+	return "osc52"
+}
+
+func (c OSC52Clipboard) Copy(text string) error {
+	encoded := base64.StdEncoding.EncodeToString([]byte(text))
+	_, err := fmt.Fprintf(c.Writer, "\x1b]52;c;%s\a", encoded)
+	return err
+}
+
+// FileClipboard writes the text to a file, for environments with no clipboard.
+type FileClipboard struct {
+	Path string
+}
+
+func (c FileClipboard) Name() string {
+	return "file:" + c.Path
+}
+
+func (c FileClipboard) Copy(text string) error {
+	return os.WriteFile(c.Path, []byte(text+"\n"), 0o644)
+}
+
+type noopClipboard struct{}
+
+func (noopClipboard) Name() string {
+	return "none"
+}
+
+func (noopClipboard) Copy(string) error {
+	return nil
+}
+
+// clipboardEnv is the part of the environment detection looks at, so that it
+// can be faked in tests.
+type clipboardEnv struct {
+	goos     string
+	getenv   func(string) string
+	lookPath func(string) (string, error)
+	// terminal is stdout or stderr, whichever is a terminal, or nil if
+	// neither is.
+	terminal io.Writer
+	// stderr is where an explicitly chosen osc52 backend writes when there is
+	// no terminal, since stdout may be piped into another program.
+	stderr io.Writer
+	// warn is where detection reports falling back to no clipboard.
+	warn io.Writer
+}
+
+func currentClipboardEnv() clipboardEnv {
+	var terminal io.Writer
+	for _, f := range []*os.File{os.Stdout, os.Stderr} {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			terminal = f
+			break
+		}
+	}
+
+	return clipboardEnv{
+		goos:     runtime.GOOS,
+		getenv:   os.Getenv,
+		lookPath: exec.LookPath,
+		terminal: terminal,
+		stderr:   os.Stderr,
+		warn:     os.Stderr,
+	}
+}
+
+// NewClipboard returns the backend with the given name: a clipboard program
+// such as xclip, "osc52", "file:PATH" or "none". An empty name detects the
+// first backend usable in the current environment, and if there is none,
+// warns once on stderr and returns the "none" backend, which copies nothing.
+func NewClipboard(name string) (Clipboard, error) {
+	return currentClipboardEnv().clipboard(name)
+}
+
+func (env clipboardEnv) clipboard(name string) (Clipboard, error) {
+	switch {
+	case name == "":
+		return env.detect()
+	case name == "none":
+		return noopClipboard{}, nil
+	case name == "osc52":
+		writer := env.terminal
+		if writer == nil {
+			writer = env.stderr
+		}
+		return OSC52Clipboard{writer}, nil
+	case strings.HasPrefix(name, "file:"):
+		return FileClipboard{strings.TrimPrefix(name, "file:")}, nil
+	}
+
+	for _, c := range commandClipboards {
+		if c.name != name {
+			continue
+		}
+		if _, err := env.lookPath(c.name); err != nil {
+			return nil, fmt.Errorf("clipboard backend %s: %w", name, err)
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("unknown clipboard backend %q", name)
+}
+
+func (env clipboardEnv) detect() (Clipboard, error) {
+	if name := env.getenv(ClipboardEnv); name != "" {
+		return env.clipboard(name)
+	}
+
+	for _, c := range commandClipboards {
+		if c.name == "pbcopy" && env.goos != "darwin" {
+			continue
+		}
+		if c.display != "" && env.getenv(c.display) == "" {
+			continue
+		}
+		if _, err := env.lookPath(c.name); err == nil {
+			return c, nil
+		}
+	}
+
+	term := env.getenv("TERM")
+	if env.terminal != nil && term != "" && term != "dumb" {
+		return OSC52Clipboard{env.terminal}, nil
+	}
+
+	if env.warn != nil {
+		fmt.Fprintln(env.warn, "warning: answers will not be copied:", ErrNoClipboard)
+	}
+	return noopClipboard{}, nil
+}
@@ -0,0 +1,123 @@
+package util
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func fakeClipboardEnv(goos string, vars map[string]string, programs ...string) clipboardEnv {
+	return clipboardEnv{
+		goos:   goos,
+		getenv: func(key string) string { return vars[key] },
+		lookPath: func(name string) (string, error) {
+			for _, program := range programs {
+				if program == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", exec.ErrNotFound
+		},
+	}
+}
+
+func TestDetectClipboard(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      clipboardEnv
+		terminal bool
+		want     string
+	}{
+		{
+			name: "macOS",
+			env:  fakeClipboardEnv("darwin", nil, "pbcopy"),
+			want: "pbcopy",
+		},
+		{
+			name: "wayland",
+			env:  fakeClipboardEnv("linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, "wl-copy", "xclip"),
+			want: "wl-copy",
+		},
+		{
+			name: "x11 without wayland",
+			env:  fakeClipboardEnv("linux", map[string]string{"DISPLAY": ":0"}, "wl-copy", "xsel"),
+			want: "xsel",
+		},
+		{
+			name:     "headless terminal",
+			env:      fakeClipboardEnv("linux", map[string]string{"TERM": "xterm-256color"}, "xclip"),
+			terminal: true,
+			want:     "osc52",
+		},
+		{
+			name: "explicit choice",
+			env:  fakeClipboardEnv("linux", map[string]string{ClipboardEnv: "file:/tmp/answer.txt", "DISPLAY": ":0"}, "xclip"),
+			want: "file:/tmp/answer.txt",
+		},
+		{
+			name: "nothing available",
+			env:  fakeClipboardEnv("linux", map[string]string{"DISPLAY": ":0"}),
+			want: "none",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.terminal {
+				tt.env.terminal = &bytes.Buffer{}
+			}
+
+			got, err := tt.env.clipboard("")
+			if err != nil {
+				t.Fatalf("detect() error = %v", err)
+			}
+			if got.Name() != tt.want {
+				t.Errorf("detect() = %v, want %v", got.Name(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectFallbackWarns(t *testing.T) {
+	var warning bytes.Buffer
+	env := fakeClipboardEnv("linux", nil)
+	env.warn = &warning
+
+	got, err := env.clipboard("")
+	if err != nil || got.Name() != "none" {
+		t.Fatalf("detect() = %v, %v, want none", got, err)
+	}
+	if err := got.Copy("42"); err != nil {
+		t.Errorf("Copy() error = %v", err)
+	}
+	if !strings.Contains(warning.String(), ErrNoClipboard.Error()) || strings.Count(warning.String(), "\n") != 1 {
+		t.Errorf("warning = %q, want one line mentioning %v", warning.String(), ErrNoClipboard)
+	}
+}
+
+func TestOSC52Clipboard(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (OSC52Clipboard{&buf}).Copy("42"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "\x1b]52;c;NDI=\a"; got != want {
+		t.Errorf("Copy() wrote %q, want %q", got, want)
+	}
+}
+
+func TestExplicitOSC52WithoutTerminal(t *testing.T) {
+	var stderr bytes.Buffer
+	env := fakeClipboardEnv("linux", nil)
+	env.stderr = &stderr
+
+	got, err := env.clipboard("osc52")
+	if err != nil {
+		t.Fatalf("clipboard(osc52) error = %v", err)
+	}
+	if err := got.Copy("42"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := stderr.String(), "\x1b]52;c;NDI=\a"; got != want {
+		t.Errorf("Copy() wrote %q to stderr, want %q", got, want)
+	}
+}
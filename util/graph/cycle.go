@@ -0,0 +1,101 @@
+package graph
+
+// Cycle describes the sequence start, next(start), next(next(start)), ... of a
+// deterministic walk over finitely many states. The walk takes Prefix steps to
+// enter a loop that then repeats every Length steps.
+type Cycle struct {
+	Prefix int
+	Length int
+	// Hits are the steps in [0, Prefix+Length) at which the walk is on a
+	// state of interest. A hit at step h >= Prefix recurs at h + k*Length.
+	Hits []int
+}
+
+// Floyd finds the cycle of a walk with Floyd's tortoise and hare.
+func Floyd[S comparable](start S, next func(S) S) (prefix int, length int) {
+	tortoise, hare := next(start), next(next(start))
+	for tortoise != hare {
+		tortoise, hare = next(tortoise), next(next(hare))
+	}
+
+	tortoise = start
+	for tortoise != hare {
+		tortoise, hare = next(tortoise), next(hare)
+		prefix += 1
+	}
+
+	length = 1
+	for hare = next(tortoise); tortoise != hare; hare = next(hare) {
+		length += 1
+	}
+
+	return prefix, length
+}
+
+// Brent finds the cycle of a walk with Brent's algorithm, which calls next
+// fewer times than Floyd.
+func Brent[S comparable](start S, next func(S) S) (prefix int, length int) {
+	power, length := 1, 1
+	tortoise, hare := start, next(start)
+	for tortoise != hare {
+		if power == length {
+			tortoise = hare
+			power *= 2
+			length = 0
+		}
+		hare = next(hare)
+		length += 1
+	}
+
+	tortoise, hare = start, start
+	for i := 0; i < length; i++ {
+		hare = next(hare)
+	}
+	for tortoise != hare {
+		tortoise, hare = next(tortoise), next(hare)
+		prefix += 1
+	}
+
+	return prefix, length
+}
+
+// FindCycle finds the cycle of a walk and the steps at which it is on a state
+// satisfying isHit.
+func FindCycle[S comparable](start S, next func(S) S, isHit func(S) bool) Cycle {
+	prefix, length := Brent(start, next)
+	cycle := Cycle{Prefix: prefix, Length: length, Hits: []int{}}
+
+	state := start
+	for steps := 0; steps < prefix+length; steps++ {
+		if isHit(state) {
+			cycle.Hits = append(cycle.Hits, steps)
+		}
+		state = next(state)
+	}
+
+	return cycle
+}
+
+// IsHit reports whether the walk is on a state of interest after steps steps.
+func (c Cycle) IsHit(steps int) bool {
+	if steps >= c.Prefix+c.Length {
+		steps = c.Prefix + (steps-c.Prefix)%c.Length
+	}
+	for _, hit := range c.Hits {
+		if hit == steps {
+			return true
+		}
+	}
+	return false
+}
+
+// CycleHits returns the hits that recur every Length steps.
+func (c Cycle) CycleHits() []int {
+	hits := []int{}
+	for _, hit := range c.Hits {
+		if hit >= c.Prefix {
+			hits = append(hits, hit)
+		}
+	}
+	return hits
+}
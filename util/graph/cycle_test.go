@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFindCycle(t *testing.T) {
+	tests := []struct {
+		name       string
+		start      int
+		next       func(int) int
+		isHit      func(int) bool
+		wantPrefix int
+		wantLength int
+		wantHits   []int
+	}{
+		{
+			// 0 -> 1 -> 2 -> 3 -> 4 -> 5 -> 2
+			name:       "rho",
+			start:      0,
+			next:       func(n int) int { return []int{1, 2, 3, 4, 5, 2}[n] },
+			isHit:      func(n int) bool { return n == 1 || n == 4 },
+			wantPrefix: 2,
+			wantLength: 4,
+			wantHits:   []int{1, 4},
+		},
+		{
+			name:       "pure cycle",
+			start:      3,
+			next:       func(n int) int { return (n + 7) % 10 },
+			isHit:      func(n int) bool { return n%2 == 0 },
+			wantPrefix: 0,
+			wantLength: 10,
+			wantHits:   []int{1, 3, 5, 7, 9},
+		},
+		{
+			name:       "fixed point",
+			start:      5,
+			next:       func(n int) int { return max(n-1, 0) },
+			isHit:      func(n int) bool { return n == 0 },
+			wantPrefix: 5,
+			wantLength: 1,
+			wantHits:   []int{5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, length := Floyd(tt.start, tt.next)
+			if prefix != tt.wantPrefix || length != tt.wantLength {
+				t.Errorf("Floyd() = %d, %d, want %d, %d", prefix, length, tt.wantPrefix, tt.wantLength)
+			}
+
+			got := FindCycle(tt.start, tt.next, tt.isHit)
+			if got.Prefix != tt.wantPrefix || got.Length != tt.wantLength || !slices.Equal(got.Hits, tt.wantHits) {
+				t.Errorf("FindCycle() = %+v, want prefix %d, length %d, hits %v", got, tt.wantPrefix, tt.wantLength, tt.wantHits)
+			}
+
+			state := tt.start
+			for steps := 0; steps < 50; steps++ {
+				if got.IsHit(steps) != tt.isHit(state) {
+					t.Errorf("IsHit(%d) = %v, want %v", steps, got.IsHit(steps), tt.isHit(state))
+				}
+				state = tt.next(state)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+// Package graph has a keyed directed or undirected graph, searches over it or
+// over any graph given as a neighbors function, and cycle detection for
+// deterministic walks.
+package graph
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+// Graph is a directed or undirected graph whose vertices are looked up by a
+// comparable key and carry data of type T. Parallel edges are allowed and
+// neighbors keep the order their edges were added in, so a vertex's first and
+// second edge can stand for a left and right turn.
+type Graph[K comparable, T any] struct {
+	directed bool
+	nextId   int
+	vertices map[int]*Vertex[K, T]
+	ids      map[K]int
+	// out holds the edges leaving each vertex and in the ids of the vertices
+	// with an edge arriving at it. For undirected graphs both hold every edge
+	// in both directions.
+	out map[int][]edge
+	in  map[int][]int
+}
+
+type edge struct {
+	to     int
+	weight int
+}
+
+type Vertex[K comparable, T any] struct {
+	Id   int
+	Key  K
+	Data T
+}
+
+func New[K comparable, T any]() *Graph[K, T] {
+	return newGraph[K, T](false)
+}
+
+func NewDirected[K comparable, T any]() *Graph[K, T] {
+	return newGraph[K, T](true)
+}
+
+func newGraph[K comparable, T any](directed bool) *Graph[K, T] {
+	return &Graph[K, T]{
+		directed: directed,
+		vertices: make(map[int]*Vertex[K, T]),
+		ids:      make(map[K]int),
+		out:      make(map[int][]edge),
+		in:       make(map[int][]int),
+	}
+}
+
+func (g *Graph[K, T]) Directed() bool {
+	return g.directed
+}
+
+func (g *Graph[K, T]) Len() int {
+	return len(g.vertices)
+}
+
+// AddVertex adds a vertex for key, or replaces the data of the existing one.
+func (g *Graph[K, T]) AddVertex(key K, data T) *Vertex[K, T] {
+	if vertex, ok := g.Vertex(key); ok {
+		vertex.Data = data
+		return vertex
+	}
+
+	vertex := &Vertex[K, T]{
+		Id:   g.nextId,
+		Key:  key,
+		Data: data,
+	}
+	g.vertices[vertex.Id] = vertex
+	g.ids[key] = vertex.Id
+	g.nextId += 1
+
+	return vertex
+}
+
+func (g *Graph[K, T]) Vertex(key K) (*Vertex[K, T], bool) {
+	id, ok := g.ids[key]
+	if !ok {
+		return nil, false
+	}
+	return g.vertices[id], true
+}
+
+// Vertices returns every vertex in the order they were added.
+func (g *Graph[K, T]) Vertices() []*Vertex[K, T] {
+	vertices := lo.Values(g.vertices)
+	slices.SortFunc(vertices, func(a *Vertex[K, T], b *Vertex[K, T]) int {
+		return a.Id - b.Id
+	})
+	return vertices
+}
+
+// AddEdge adds an edge of weight 1 from a to b, which is also an edge from b
+// to a when the graph is undirected. Both vertices must already exist.
+func (g *Graph[K, T]) AddEdge(a K, b K) error {
+	return g.AddWeightedEdge(a, b, 1)
+}
+
+func (g *Graph[K, T]) AddWeightedEdge(a K, b K, weight int) error {
+	aId, bId, err := g.edgeIds(a, b)
+	if err != nil {
+		return err
+	}
+
+	g.out[aId] = append(g.out[aId], edge{bId, weight})
+	g.in[bId] = append(g.in[bId], aId)
+	if !g.directed && aId != bId {
+		g.out[bId] = append(g.out[bId], edge{aId, weight})
+		g.in[aId] = append(g.in[aId], bId)
+	}
+
+	return nil
+}
+
+func (g *Graph[K, T]) HasEdge(a K, b K) bool {
+	aId, bId, err := g.edgeIds(a, b)
+	return err == nil && slices.ContainsFunc(g.out[aId], func(e edge) bool { return e.to == bId })
+}
+
+// RemoveEdge removes every edge from a to b.
+func (g *Graph[K, T]) RemoveEdge(a K, b K) error {
+	aId, bId, err := g.edgeIds(a, b)
+	if err != nil {
+		return err
+	}
+
+	g.removeEdges(aId, bId)
+	if !g.directed {
+		g.removeEdges(bId, aId)
+	}
+	return nil
+}
+
+func (g *Graph[K, T]) removeEdges(from int, to int) {
+	g.out[from] = slices.DeleteFunc(g.out[from], func(e edge) bool { return e.to == to })
+	g.in[to] = slices.DeleteFunc(g.in[to], func(id int) bool { return id == from })
+}
+
+// RemoveVertex removes the vertex for key along with its edges.
+func (g *Graph[K, T]) RemoveVertex(key K) error {
+	id, ok := g.ids[key]
+	if !ok {
+		return fmt.Errorf("vertex %v not in graph", key)
+	}
+
+	for _, e := range slices.Clone(g.out[id]) {
+		g.removeEdges(id, e.to)
+	}
+	for _, from := range slices.Clone(g.in[id]) {
+		g.removeEdges(from, id)
+	}
+
+	delete(g.out, id)
+	delete(g.in, id)
+	delete(g.ids, key)
+	delete(g.vertices, id)
+
+	return nil
+}
+
+// Neighbors returns the vertices reachable from key over one edge, in the
+// order the edges were added. A vertex appears once per parallel edge.
+func (g *Graph[K, T]) Neighbors(key K) []*Vertex[K, T] {
+	id, ok := g.ids[key]
+	if !ok {
+		return nil
+	}
+
+	neighbors := make([]*Vertex[K, T], len(g.out[id]))
+	for i, e := range g.out[id] {
+		neighbors[i] = g.vertices[e.to]
+	}
+	return neighbors
+}
+
+// Edges returns the edges leaving key, in the order they were added.
+func (g *Graph[K, T]) Edges(key K) []Edge[K] {
+	id, ok := g.ids[key]
+	if !ok {
+		return nil
+	}
+
+	edges := make([]Edge[K], len(g.out[id]))
+	for i, e := range g.out[id] {
+		edges[i] = Edge[K]{To: g.vertices[e.to].Key, Weight: e.weight}
+	}
+	return edges
+}
+
+// NeighborKeys is Neighbors returning only the keys, in the form taken by the
+// traversal functions.
+func (g *Graph[K, T]) NeighborKeys(key K) []K {
+	id, ok := g.ids[key]
+	if !ok {
+		return nil
+	}
+
+	keys := make([]K, len(g.out[id]))
+	for i, e := range g.out[id] {
+		keys[i] = g.vertices[e.to].Key
+	}
+	return keys
+}
+
+func (g *Graph[K, T]) edgeIds(a K, b K) (int, int, error) {
+	aId, ok := g.ids[a]
+	if !ok {
+		return 0, 0, fmt.Errorf("vertex %v not in graph", a)
+	}
+	bId, ok := g.ids[b]
+	if !ok {
+		return 0, 0, fmt.Errorf("vertex %v not in graph", b)
+	}
+	return aId, bId, nil
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func neighborKeys[K comparable, T any](g *Graph[K, T], key K) []K {
+	keys := []K{}
+	for _, vertex := range g.Neighbors(key) {
+		keys = append(keys, vertex.Key)
+	}
+	return keys
+}
+
+func TestDirectedGraph(t *testing.T) {
+	g := NewDirected[string, int]()
+	for i, key := range []string{"AAA", "BBB", "ZZZ"} {
+		g.AddVertex(key, i)
+	}
+
+	edges := [][2]string{{"AAA", "BBB"}, {"AAA", "BBB"}, {"BBB", "AAA"}, {"BBB", "ZZZ"}, {"ZZZ", "ZZZ"}}
+	for _, edge := range edges {
+		if err := g.AddEdge(edge[0], edge[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.AddEdge("AAA", "CCC"); err == nil {
+		t.Errorf("AddEdge() to a missing vertex error = nil, want error")
+	}
+
+	if got := neighborKeys(g, "AAA"); !slices.Equal(got, []string{"BBB", "BBB"}) {
+		t.Errorf("Neighbors(AAA) = %v, want [BBB BBB]", got)
+	}
+	if got := neighborKeys(g, "BBB"); !slices.Equal(got, []string{"AAA", "ZZZ"}) {
+		t.Errorf("Neighbors(BBB) = %v, want [AAA ZZZ]", got)
+	}
+	if g.HasEdge("ZZZ", "BBB") {
+		t.Errorf("HasEdge(ZZZ, BBB) = true in a directed graph")
+	}
+
+	if err := g.RemoveVertex("BBB"); err != nil {
+		t.Fatal(err)
+	}
+	if got := neighborKeys(g, "AAA"); len(got) != 0 {
+		t.Errorf("Neighbors(AAA) after removing BBB = %v, want none", got)
+	}
+	if _, ok := g.Vertex("BBB"); ok || g.Len() != 2 {
+		t.Errorf("BBB still in graph after RemoveVertex")
+	}
+
+	vertex, ok := g.Vertex("ZZZ")
+	if !ok || vertex.Data != 2 || vertex.Id != 2 {
+		t.Errorf("Vertex(ZZZ) = %+v, %v, want data 2 and id 2", vertex, ok)
+	}
+}
+
+func TestUndirectedGraph(t *testing.T) {
+	g := New[int, struct{}]()
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i, struct{}{})
+	}
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 2)
+
+	if !g.HasEdge(1, 0) {
+		t.Errorf("HasEdge(1, 0) = false, want true in an undirected graph")
+	}
+	if got := neighborKeys(g, 2); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Neighbors(2) = %v, want [1 2]", got)
+	}
+
+	if err := g.RemoveEdge(2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if g.HasEdge(1, 2) || g.HasEdge(2, 1) {
+		t.Errorf("edge 1-2 still present after RemoveEdge(2, 1)")
+	}
+
+	other := New[int, struct{}]()
+	if vertex := other.AddVertex(7, struct{}{}); vertex.Id != 0 {
+		t.Errorf("first vertex of a new graph has id %d, want 0", vertex.Id)
+	}
+}
@@ -0,0 +1,181 @@
+package graph
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// The traversal functions work on any graph given as a function from a vertex
+// to its neighbors, so that implicit graphs such as the states of a walk can
+// be searched without building a Graph first. The Graph methods of the same
+// names call them with the graph's own edges.
+
+// Edge is an edge to a vertex with the cost of following it.
+type Edge[K comparable] struct {
+	To     K
+	Weight int
+}
+
+// BFS visits every vertex reachable from start in breadth-first order, passing
+// its distance in edges from start. It stops early when visit returns false.
+func BFS[K comparable](start K, neighbors func(K) []K, visit func(key K, depth int) bool) {
+	seen := map[K]bool{start: true}
+	queue := []K{start}
+	for depth := 0; len(queue) > 0; depth++ {
+		next := []K{}
+		for _, key := range queue {
+			if !visit(key, depth) {
+				return
+			}
+			for _, neighbor := range neighbors(key) {
+				if !seen[neighbor] {
+					seen[neighbor] = true
+					next = append(next, neighbor)
+				}
+			}
+		}
+		queue = next
+	}
+}
+
+// DFS visits every vertex reachable from start in depth-first preorder,
+// following edges in order. It stops early when visit returns false.
+func DFS[K comparable](start K, neighbors func(K) []K, visit func(key K) bool) {
+	seen := map[K]bool{}
+	stack := []K{start}
+	for len(stack) > 0 {
+		key := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if !visit(key) {
+			return
+		}
+
+		// Push in reverse so the first neighbor is visited first.
+		next := neighbors(key)
+		for i := len(next) - 1; i >= 0; i-- {
+			if !seen[next[i]] {
+				stack = append(stack, next[i])
+			}
+		}
+	}
+}
+
+// Reachable returns every vertex reachable from start, including start, in
+// breadth-first order.
+func Reachable[K comparable](start K, neighbors func(K) []K) []K {
+	reachable := []K{}
+	BFS(start, neighbors, func(key K, _ int) bool {
+		reachable = append(reachable, key)
+		return true
+	})
+	return reachable
+}
+
+// Dijkstra finds the cheapest path from start to a vertex satisfying isGoal.
+// Edge weights must not be negative. It returns the path including both ends
+// and its cost, or false when no goal is reachable.
+func Dijkstra[K comparable](start K, edges func(K) []Edge[K], isGoal func(K) bool) ([]K, int, bool) {
+	return AStar(start, edges, isGoal, func(K) int { return 0 })
+}
+
+// AStar is Dijkstra guided by a heuristic estimating the remaining cost to a
+// goal. The path found is the cheapest as long as the heuristic is consistent:
+// it never drops by more than the weight of the edge taken, and is 0 at goals.
+func AStar[K comparable](start K, edges func(K) []Edge[K], isGoal func(K) bool, heuristic func(K) int) ([]K, int, bool) {
+	costs := map[K]int{start: 0}
+	previous := map[K]K{}
+	done := map[K]bool{}
+
+	queue := &priorityQueue[K]{{key: start, priority: heuristic(start)}}
+	for queue.Len() > 0 {
+		key := heap.Pop(queue).(queueItem[K]).key
+		if done[key] {
+			continue
+		}
+		done[key] = true
+
+		if isGoal(key) {
+			return buildPath(previous, start, key), costs[key], true
+		}
+
+		for _, e := range edges(key) {
+			cost := costs[key] + e.Weight
+			if known, ok := costs[e.To]; ok && known <= cost {
+				continue
+			}
+			costs[e.To] = cost
+			previous[e.To] = key
+			heap.Push(queue, queueItem[K]{key: e.To, priority: cost + heuristic(e.To)})
+		}
+	}
+
+	return nil, 0, false
+}
+
+func buildPath[K comparable](previous map[K]K, start K, end K) []K {
+	path := []K{end}
+	for key := end; key != start; {
+		key = previous[key]
+		path = append(path, key)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+type queueItem[K any] struct {
+	key      K
+	priority int
+}
+
+// priorityQueue is a min-heap of queue items for container/heap.
+type priorityQueue[K any] []queueItem[K]
+
+func (q priorityQueue[K]) Len() int           { return len(q) }
+func (q priorityQueue[K]) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q priorityQueue[K]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *priorityQueue[K]) Push(x any)        { *q = append(*q, x.(queueItem[K])) }
+
+func (q *priorityQueue[K]) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// BFS visits nothing when start is not in the graph.
+func (g *Graph[K, T]) BFS(start K, visit func(key K, depth int) bool) {
+	if _, ok := g.ids[start]; !ok {
+		return
+	}
+	BFS(start, g.NeighborKeys, visit)
+}
+
+// DFS visits nothing when start is not in the graph.
+func (g *Graph[K, T]) DFS(start K, visit func(key K) bool) {
+	if _, ok := g.ids[start]; !ok {
+		return
+	}
+	DFS(start, g.NeighborKeys, visit)
+}
+
+func (g *Graph[K, T]) Reachable(start K) []K {
+	if _, ok := g.ids[start]; !ok {
+		return nil
+	}
+	return Reachable(start, g.NeighborKeys)
+}
+
+// ShortestPath finds the cheapest path between two vertices by edge weight.
+func (g *Graph[K, T]) ShortestPath(start K, goal K) ([]K, int, bool) {
+	return Dijkstra(start, g.Edges, func(key K) bool { return key == goal })
+}
+
+// AStar finds the cheapest path between two vertices guided by a heuristic.
+func (g *Graph[K, T]) AStar(start K, goal K, heuristic func(K) int) ([]K, int, bool) {
+	return AStar(start, g.Edges, func(key K) bool { return key == goal }, heuristic)
+}
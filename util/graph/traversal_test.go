@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+type point struct {
+	row, col int
+}
+
+// mazeEdges treats the maze as an implicit graph where every open cell has an
+// edge of weight 1 to each open cell next to it.
+func mazeEdges(maze []string) func(point) []Edge[point] {
+	return func(p point) []Edge[point] {
+		edges := []Edge[point]{}
+		for _, d := range []point{{-1, 0}, {0, 1}, {1, 0}, {0, -1}} {
+			next := point{p.row + d.row, p.col + d.col}
+			if next.row < 0 || next.row >= len(maze) || next.col < 0 || next.col >= len(maze[0]) {
+				continue
+			}
+			if maze[next.row][next.col] != '#' {
+				edges = append(edges, Edge[point]{To: next, Weight: 1})
+			}
+		}
+		return edges
+	}
+}
+
+func TestShortestPathInMaze(t *testing.T) {
+	maze := strings.Split(`S...#....
+.##.#.##.
+.#..#..#.
+.#.##..#.
+.......#E`, "\n")
+	start, goal := point{0, 0}, point{4, 8}
+	isGoal := func(p point) bool { return p == goal }
+	manhattan := func(p point) int {
+		return max(goal.row-p.row, p.row-goal.row) + max(goal.col-p.col, p.col-goal.col)
+	}
+
+	path, cost, ok := Dijkstra(start, mazeEdges(maze), isGoal)
+	if !ok || cost != 20 || len(path) != 21 || path[0] != start || path[20] != goal {
+		t.Errorf("Dijkstra() = %v, %d, %v, want a 20 step path", path, cost, ok)
+	}
+
+	_, aStarCost, ok := AStar(start, mazeEdges(maze), isGoal, manhattan)
+	if !ok || aStarCost != cost {
+		t.Errorf("AStar() cost = %d, %v, want %d", aStarCost, ok, cost)
+	}
+
+	walled := point{0, 4}
+	if _, _, ok := Dijkstra(start, mazeEdges(maze), func(p point) bool { return p == walled }); ok {
+		t.Errorf("Dijkstra() found a path into a wall")
+	}
+}
+
+func TestGraphTraversal(t *testing.T) {
+	g := NewDirected[string, struct{}]()
+	for _, key := range []string{"a", "b", "c", "d", "e", "x"} {
+		g.AddVertex(key, struct{}{})
+	}
+	g.AddWeightedEdge("a", "b", 1)
+	g.AddWeightedEdge("a", "c", 4)
+	g.AddWeightedEdge("b", "c", 1)
+	g.AddWeightedEdge("b", "d", 5)
+	g.AddWeightedEdge("c", "d", 1)
+	g.AddWeightedEdge("d", "e", 1)
+	g.AddWeightedEdge("x", "a", 1)
+
+	depths := map[string]int{}
+	g.BFS("a", func(key string, depth int) bool {
+		depths[key] = depth
+		return true
+	})
+	want := map[string]int{"a": 0, "b": 1, "c": 1, "d": 2, "e": 3}
+	for key, depth := range want {
+		if depths[key] != depth {
+			t.Errorf("BFS depth of %s = %d, want %d", key, depths[key], depth)
+		}
+	}
+
+	order := []string{}
+	g.DFS("a", func(key string) bool {
+		order = append(order, key)
+		return key != "d"
+	})
+	if !slices.Equal(order, []string{"a", "b", "c", "d"}) {
+		t.Errorf("DFS order = %v, want [a b c d]", order)
+	}
+
+	if got := g.Reachable("a"); len(got) != 5 || slices.Contains(got, "x") {
+		t.Errorf("Reachable(a) = %v, want a to e", got)
+	}
+
+	visited := []string{}
+	g.BFS("missing", func(key string, _ int) bool {
+		visited = append(visited, key)
+		return true
+	})
+	g.DFS("missing", func(key string) bool {
+		visited = append(visited, key)
+		return true
+	})
+	if len(visited) != 0 || g.Reachable("missing") != nil {
+		t.Errorf("traversals from a missing vertex visited %v", visited)
+	}
+
+	path, cost, ok := g.ShortestPath("a", "e")
+	if !ok || cost != 4 || !slices.Equal(path, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("ShortestPath(a, e) = %v, %d, %v, want [a b c d e], 4", path, cost, ok)
+	}
+	if _, _, ok := g.ShortestPath("e", "a"); ok {
+		t.Errorf("ShortestPath(e, a) found a path against the edges")
+	}
+}
@@ -0,0 +1,237 @@
+// Package grid is a rectangular 2D grid of cells, as found in most puzzles
+// whose input is a map drawn with characters.
+package grid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Point is a cell position. Rows grow downwards and columns to the right.
+type Point struct {
+	Row int
+	Col int
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{p.Row + q.Row, p.Col + q.Col}
+}
+
+var (
+	Up    = Point{-1, 0}
+	Right = Point{0, 1}
+	Down  = Point{1, 0}
+	Left  = Point{0, -1}
+
+	// Directions4 are the orthogonal directions, clockwise from up.
+	Directions4 = []Point{Up, Right, Down, Left}
+	// Directions8 adds the diagonals, clockwise from up.
+	Directions8 = []Point{Up, {-1, 1}, Right, {1, 1}, Down, {1, -1}, Left, {-1, -1}}
+)
+
+type Grid[T any] struct {
+	rows  int
+	cols  int
+	cells []T
+}
+
+// New returns a grid of the given size with every cell set to fill.
+func New[T any](rows int, cols int, fill T) Grid[T] {
+	cells := make([]T, rows*cols)
+	for i := range cells {
+		cells[i] = fill
+	}
+	return Grid[T]{rows, cols, cells}
+}
+
+// Parse reads a grid of characters, one row per line.
+func Parse(text string) (Grid[rune], error) {
+	return ParseFunc(text, func(_ Point, char rune) (rune, error) {
+		return char, nil
+	})
+}
+
+// ParseFunc reads a grid one character per cell, converting each with parse.
+// Every line must have the same number of characters.
+func ParseFunc[T any](text string, parse func(p Point, char rune) (T, error)) (Grid[T], error) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	g := Grid[T]{rows: len(lines)}
+	for row, line := range lines {
+		chars := []rune(line)
+		if row == 0 {
+			g.cols = len(chars)
+			g.cells = make([]T, 0, g.rows*g.cols)
+		} else if len(chars) != g.cols {
+			return Grid[T]{}, fmt.Errorf("line %d has %d cells, want %d", row+1, len(chars), g.cols)
+		}
+
+		for col, char := range chars {
+			cell, err := parse(Point{row, col}, char)
+			if err != nil {
+				return Grid[T]{}, fmt.Errorf("line %d column %d: %w", row+1, col+1, err)
+			}
+			g.cells = append(g.cells, cell)
+		}
+	}
+
+	return g, nil
+}
+
+func (g Grid[T]) Rows() int {
+	return g.rows
+}
+
+func (g Grid[T]) Cols() int {
+	return g.cols
+}
+
+func (g Grid[T]) InBounds(p Point) bool {
+	return 0 <= p.Row && p.Row < g.rows && 0 <= p.Col && p.Col < g.cols
+}
+
+// At returns the cell at p, panicking if it is out of bounds.
+func (g Grid[T]) At(p Point) T {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("grid: %v out of bounds of %dx%d grid", p, g.rows, g.cols))
+	}
+	return g.cells[p.Row*g.cols+p.Col]
+}
+
+// Get returns the cell at p, or false if p is out of bounds.
+func (g Grid[T]) Get(p Point) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Row*g.cols+p.Col], true
+}
+
+// Set changes the cell at p, reporting false if p is out of bounds.
+func (g Grid[T]) Set(p Point, value T) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+	g.cells[p.Row*g.cols+p.Col] = value
+	return true
+}
+
+// Points returns every position in row-major order.
+func (g Grid[T]) Points() []Point {
+	points := make([]Point, 0, len(g.cells))
+	for row := 0; row < g.rows; row++ {
+		for col := 0; col < g.cols; col++ {
+			points = append(points, Point{row, col})
+		}
+	}
+	return points
+}
+
+// Neighbors4 returns the orthogonal neighbors of p that are in bounds.
+func (g Grid[T]) Neighbors4(p Point) []Point {
+	return g.neighbors(p, Directions4)
+}
+
+// Neighbors8 returns the orthogonal and diagonal neighbors of p that are in
+// bounds.
+func (g Grid[T]) Neighbors8(p Point) []Point {
+	return g.neighbors(p, Directions8)
+}
+
+func (g Grid[T]) neighbors(p Point, directions []Point) []Point {
+	neighbors := make([]Point, 0, len(directions))
+	for _, direction := range directions {
+		if neighbor := p.Add(direction); g.InBounds(neighbor) {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors
+}
+
+// Row returns a copy of a row's cells.
+func (g Grid[T]) Row(row int) []T {
+	return append([]T{}, g.cells[row*g.cols:(row+1)*g.cols]...)
+}
+
+// Col returns a copy of a column's cells.
+func (g Grid[T]) Col(col int) []T {
+	cells := make([]T, g.rows)
+	for row := range cells {
+		cells[row] = g.cells[row*g.cols+col]
+	}
+	return cells
+}
+
+// Region returns a copy of the rows x cols region with its top left corner at
+// p, clipped to the grid.
+func (g Grid[T]) Region(p Point, rows int, cols int) Grid[T] {
+	top, left := max(p.Row, 0), max(p.Col, 0)
+	bottom, right := min(p.Row+rows, g.rows), min(p.Col+cols, g.cols)
+	if bottom <= top || right <= left {
+		return Grid[T]{}
+	}
+
+	region := Grid[T]{rows: bottom - top, cols: right - left}
+	for row := top; row < bottom; row++ {
+		region.cells = append(region.cells, g.cells[row*g.cols+left:row*g.cols+right]...)
+	}
+	return region
+}
+
+// Transpose returns the grid mirrored along its main diagonal.
+func (g Grid[T]) Transpose() Grid[T] {
+	return g.remap(g.cols, g.rows, func(p Point) Point {
+		return Point{p.Col, p.Row}
+	})
+}
+
+// RotateClockwise returns the grid turned a quarter turn clockwise.
+func (g Grid[T]) RotateClockwise() Grid[T] {
+	return g.remap(g.cols, g.rows, func(p Point) Point {
+		return Point{g.rows - 1 - p.Col, p.Row}
+	})
+}
+
+// RotateCounterClockwise returns the grid turned a quarter turn
+// counterclockwise.
+func (g Grid[T]) RotateCounterClockwise() Grid[T] {
+	return g.remap(g.cols, g.rows, func(p Point) Point {
+		return Point{p.Col, g.cols - 1 - p.Row}
+	})
+}
+
+// remap builds a rows x cols grid whose cell at p is g's cell at source(p).
+func (g Grid[T]) remap(rows int, cols int, source func(p Point) Point) Grid[T] {
+	remapped := Grid[T]{rows, cols, make([]T, rows*cols)}
+	for _, p := range remapped.Points() {
+		remapped.Set(p, g.At(source(p)))
+	}
+	return remapped
+}
+
+// Format draws the grid one line per row, with each cell drawn by format.
+func (g Grid[T]) Format(format func(T) string) string {
+	var sb strings.Builder
+	for row := 0; row < g.rows; row++ {
+		if row > 0 {
+			sb.WriteByte('\n')
+		}
+		for _, cell := range g.cells[row*g.cols : (row+1)*g.cols] {
+			sb.WriteString(format(cell))
+		}
+	}
+	return sb.String()
+}
+
+// String draws rune and string cells as they are and other cells with fmt.
+func (g Grid[T]) String() string {
+	return g.Format(func(cell T) string {
+		switch cell := any(cell).(type) {
+		case rune:
+			return string(cell)
+		case string:
+			return cell
+		}
+		return fmt.Sprint(cell)
+	})
+}
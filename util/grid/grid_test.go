@@ -0,0 +1,83 @@
+package grid
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	g, err := Parse("abc\ndef\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Rows() != 2 || g.Cols() != 3 || g.At(Point{1, 2}) != 'f' {
+		t.Errorf("Parse() = %dx%d grid %q", g.Rows(), g.Cols(), g.String())
+	}
+	if _, ok := g.Get(Point{2, 0}); ok {
+		t.Errorf("Get() out of bounds ok = true")
+	}
+	if g.Set(Point{-1, 0}, 'x') {
+		t.Errorf("Set() out of bounds = true")
+	}
+
+	if _, err := Parse("abc\nde"); err == nil {
+		t.Errorf("Parse() of a ragged grid error = nil, want error")
+	}
+
+	digits, err := ParseFunc("12\n34", func(_ Point, char rune) (int, error) {
+		return strconv.Atoi(string(char))
+	})
+	if err != nil || digits.At(Point{1, 0}) != 3 || digits.String() != "12\n34" {
+		t.Errorf("ParseFunc() = %v, %v", digits, err)
+	}
+	if _, err := ParseFunc("1x", func(_ Point, char rune) (int, error) {
+		return strconv.Atoi(string(char))
+	}); err == nil {
+		t.Errorf("ParseFunc() error = nil, want the cell's parse error")
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := New(3, 3, 0)
+
+	if got := g.Neighbors8(Point{1, 1}); len(got) != 8 {
+		t.Errorf("Neighbors8(center) = %v, want 8 neighbors", got)
+	}
+	if got := g.Neighbors8(Point{0, 0}); !slices.Equal(got, []Point{{0, 1}, {1, 1}, {1, 0}}) {
+		t.Errorf("Neighbors8(corner) = %v", got)
+	}
+	if got := g.Neighbors4(Point{0, 2}); !slices.Equal(got, []Point{{1, 2}, {0, 1}}) {
+		t.Errorf("Neighbors4(corner) = %v", got)
+	}
+}
+
+func TestSlicingAndRotation(t *testing.T) {
+	g, _ := Parse("abc\ndef")
+
+	if got := string(g.Row(1)); got != "def" {
+		t.Errorf("Row(1) = %q", got)
+	}
+	if got := string(g.Col(2)); got != "cf" {
+		t.Errorf("Col(2) = %q", got)
+	}
+
+	tests := []struct {
+		name string
+		got  Grid[rune]
+		want string
+	}{
+		{name: "region", got: g.Region(Point{0, 1}, 5, 5), want: "bc\nef"},
+		{name: "transpose", got: g.Transpose(), want: "ad\nbe\ncf"},
+		{name: "clockwise", got: g.RotateClockwise(), want: "da\neb\nfc"},
+		{name: "counterclockwise", got: g.RotateCounterClockwise(), want: "cf\nbe\nad"},
+		{name: "full turn", got: g.RotateClockwise().RotateClockwise().RotateClockwise().RotateClockwise(), want: "abc\ndef"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
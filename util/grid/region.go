@@ -0,0 +1,119 @@
+package grid
+
+// Span is a horizontal run of cells, from column Start up to but not
+// including column End.
+type Span struct {
+	Row   int
+	Start int
+	End   int
+}
+
+func (s Span) Len() int {
+	return s.End - s.Start
+}
+
+func (s Span) Contains(p Point) bool {
+	return p.Row == s.Row && s.Start <= p.Col && p.Col < s.End
+}
+
+// Points returns the cells of the span from left to right.
+func (s Span) Points() []Point {
+	points := make([]Point, 0, s.Len())
+	for col := s.Start; col < s.End; col++ {
+		points = append(points, Point{s.Row, col})
+	}
+	return points
+}
+
+// Runs returns every maximal horizontal run of cells satisfying match, in
+// row-major order. Runs of digits are the numbers drawn in a grid.
+func (g Grid[T]) Runs(match func(T) bool) []Span {
+	spans := []Span{}
+	for row := 0; row < g.rows; row++ {
+		start := -1
+		for col := 0; col <= g.cols; col++ {
+			matches := col < g.cols && match(g.cells[row*g.cols+col])
+			if matches && start == -1 {
+				start = col
+			} else if !matches && start != -1 {
+				spans = append(spans, Span{row, start, col})
+				start = -1
+			}
+		}
+	}
+	return spans
+}
+
+// Cells returns the cells at points, in order.
+func (g Grid[T]) Cells(points []Point) []T {
+	cells := make([]T, len(points))
+	for i, p := range points {
+		cells[i] = g.At(p)
+	}
+	return cells
+}
+
+// FloodFill returns the cells connected to start through cells satisfying
+// match, moving in the given directions, such as Directions4 or Directions8.
+// It returns nothing if start itself does not match.
+func (g Grid[T]) FloodFill(start Point, match func(T) bool, directions []Point) []Point {
+	if cell, ok := g.Get(start); !ok || !match(cell) {
+		return nil
+	}
+
+	seen := map[Point]bool{start: true}
+	region := []Point{}
+	stack := []Point{start}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		region = append(region, p)
+
+		for _, neighbor := range g.neighbors(p, directions) {
+			if !seen[neighbor] && match(g.At(neighbor)) {
+				seen[neighbor] = true
+				stack = append(stack, neighbor)
+			}
+		}
+	}
+	return region
+}
+
+// Components returns every connected region of cells satisfying match, in the
+// row-major order of their first cell.
+func (g Grid[T]) Components(match func(T) bool, directions []Point) [][]Point {
+	seen := map[Point]bool{}
+	components := [][]Point{}
+	for _, p := range g.Points() {
+		if seen[p] || !match(g.At(p)) {
+			continue
+		}
+
+		component := g.FloodFill(p, match, directions)
+		for _, q := range component {
+			seen[q] = true
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// Border returns the in-bounds cells next to any of points, in the given
+// directions, that are not themselves among points. Each appears once.
+func (g Grid[T]) Border(points []Point, directions []Point) []Point {
+	inside := make(map[Point]bool, len(points))
+	for _, p := range points {
+		inside[p] = true
+	}
+
+	border := []Point{}
+	for _, p := range points {
+		for _, neighbor := range g.neighbors(p, directions) {
+			if !inside[neighbor] {
+				inside[neighbor] = true
+				border = append(border, neighbor)
+			}
+		}
+	}
+	return border
+}
@@ -0,0 +1,62 @@
+package grid
+
+import (
+	"slices"
+	"testing"
+	"unicode"
+)
+
+func TestRuns(t *testing.T) {
+	g, _ := Parse("467..114..\n...*......\n..35..6333")
+
+	want := []Span{{0, 0, 3}, {0, 5, 8}, {2, 2, 4}, {2, 6, 10}}
+	spans := g.Runs(unicode.IsDigit)
+	if !slices.Equal(spans, want) {
+		t.Fatalf("Runs() = %v, want %v", spans, want)
+	}
+
+	if got := string(g.Cells(spans[3].Points())); got != "6333" {
+		t.Errorf("cells of %v = %q, want 6333", spans[3], got)
+	}
+	if !spans[0].Contains(Point{0, 2}) || spans[0].Contains(Point{0, 3}) || spans[0].Contains(Point{1, 0}) {
+		t.Errorf("Contains() is wrong for %v", spans[0])
+	}
+}
+
+func TestComponents(t *testing.T) {
+	g, _ := Parse("##..#\n#...#\n...#.\n##...")
+	isWall := func(char rune) bool { return char == '#' }
+
+	if got := g.Components(isWall, Directions4); len(got) != 4 {
+		t.Errorf("Components(4) found %d regions, want 4", len(got))
+	}
+	if got := g.Components(isWall, Directions8); len(got) != 3 {
+		t.Errorf("Components(8) found %d regions, want 3", len(got))
+	}
+	if got := g.FloodFill(Point{0, 0}, isWall, Directions4); len(got) != 3 {
+		t.Errorf("FloodFill() = %v, want the 3 walls in the corner", got)
+	}
+	if got := g.FloodFill(Point{0, 2}, isWall, Directions4); got != nil {
+		t.Errorf("FloodFill() from an open cell = %v, want nothing", got)
+	}
+}
+
+func TestBorder(t *testing.T) {
+	g := New(3, 4, '.')
+
+	span := Span{0, 1, 3}
+	border := g.Border(span.Points(), Directions8)
+	want := []Point{{0, 0}, {1, 0}, {1, 1}, {1, 2}, {1, 3}, {0, 3}}
+	slices.SortFunc(border, comparePoints)
+	slices.SortFunc(want, comparePoints)
+	if !slices.Equal(border, want) {
+		t.Errorf("Border() = %v, want %v", border, want)
+	}
+}
+
+func comparePoints(a Point, b Point) int {
+	if a.Row != b.Row {
+		return a.Row - b.Row
+	}
+	return a.Col - b.Col
+}
@@ -0,0 +1,207 @@
+// Package interval has half-open integer ranges and sets of them, for puzzles
+// that map whole ranges of numbers at once rather than one number at a time.
+package interval
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Interval is the half-open range of integers [Start, End). It is empty when
+// End <= Start.
+type Interval struct {
+	Start int
+	End   int
+}
+
+// New returns the interval [start, end).
+func New(start, end int) Interval {
+	return Interval{start, end}
+}
+
+// Length returns the interval [start, start+length), the form most puzzles
+// describe their ranges in.
+func Length(start, length int) Interval {
+	return Interval{start, start + length}
+}
+
+func (i Interval) Len() int {
+	return max(i.End-i.Start, 0)
+}
+
+func (i Interval) Empty() bool {
+	return i.End <= i.Start
+}
+
+func (i Interval) Contains(n int) bool {
+	return i.Start <= n && n < i.End
+}
+
+// Overlaps reports whether i and o have an integer in common.
+func (i Interval) Overlaps(o Interval) bool {
+	return !i.Intersect(o).Empty()
+}
+
+// Intersect returns the integers in both i and o, which may be empty.
+func (i Interval) Intersect(o Interval) Interval {
+	return Interval{max(i.Start, o.Start), min(i.End, o.End)}
+}
+
+// Shift returns i moved by offset.
+func (i Interval) Shift(offset int) Interval {
+	return Interval{i.Start + offset, i.End + offset}
+}
+
+// Split returns the parts of i before and from at. Either may be empty.
+func (i Interval) Split(at int) (Interval, Interval) {
+	at = min(max(at, i.Start), max(i.End, i.Start))
+	return Interval{i.Start, at}, Interval{at, i.End}
+}
+
+// Difference returns the parts of i not in o, in order: none, one or two
+// non-empty intervals.
+func (i Interval) Difference(o Interval) []Interval {
+	before, rest := i.Split(o.Start)
+	_, after := rest.Split(o.End)
+
+	parts := []Interval{}
+	for _, part := range []Interval{before, after} {
+		if !part.Empty() {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("[%d, %d)", i.Start, i.End)
+}
+
+// Set is a set of integers stored as sorted, disjoint, non-adjacent,
+// non-empty intervals. The zero value is the empty set. Sets are values: no
+// method modifies its receiver or arguments.
+type Set struct {
+	intervals []Interval
+}
+
+// Union returns the set of integers in any of intervals.
+func Union(intervals ...Interval) Set {
+	sorted := []Interval{}
+	for _, i := range intervals {
+		if !i.Empty() {
+			sorted = append(sorted, i)
+		}
+	}
+	slices.SortFunc(sorted, func(a, b Interval) int {
+		return a.Start - b.Start
+	})
+
+	merged := []Interval{}
+	for _, i := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && i.Start <= merged[last].End {
+			merged[last].End = max(merged[last].End, i.End)
+			continue
+		}
+		merged = append(merged, i)
+	}
+	return Set{merged}
+}
+
+// Intervals returns the set's intervals in order.
+func (s Set) Intervals() []Interval {
+	return slices.Clone(s.intervals)
+}
+
+func (s Set) Empty() bool {
+	return len(s.intervals) == 0
+}
+
+// Len returns the number of integers in the set.
+func (s Set) Len() int {
+	total := 0
+	for _, i := range s.intervals {
+		total += i.Len()
+	}
+	return total
+}
+
+// Min returns the smallest integer in the set, or false if it is empty.
+func (s Set) Min() (int, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	return s.intervals[0].Start, true
+}
+
+func (s Set) Contains(n int) bool {
+	i, found := slices.BinarySearchFunc(s.intervals, n, func(i Interval, n int) int {
+		if i.Contains(n) {
+			return 0
+		} else if i.Start > n {
+			return 1
+		}
+		return -1
+	})
+	return found && s.intervals[i].Contains(n)
+}
+
+// Union returns the integers in either s or o.
+func (s Set) Union(o Set) Set {
+	return Union(append(s.Intervals(), o.intervals...)...)
+}
+
+// Intersect returns the integers in both s and o.
+func (s Set) Intersect(o Set) Set {
+	result := []Interval{}
+	for a, b := 0, 0; a < len(s.intervals) && b < len(o.intervals); {
+		if i := s.intervals[a].Intersect(o.intervals[b]); !i.Empty() {
+			result = append(result, i)
+		}
+		if s.intervals[a].End < o.intervals[b].End {
+			a += 1
+		} else {
+			b += 1
+		}
+	}
+	return Set{result}
+}
+
+// Difference returns the integers in s but not in o.
+func (s Set) Difference(o Set) Set {
+	result := []Interval{}
+	b := 0
+	for _, i := range s.intervals {
+		for b < len(o.intervals) && o.intervals[b].End <= i.Start {
+			b += 1
+		}
+		for j := b; j < len(o.intervals) && o.intervals[j].Start < i.End; j++ {
+			before, rest := i.Split(o.intervals[j].Start)
+			if !before.Empty() {
+				result = append(result, before)
+			}
+			_, i = rest.Split(o.intervals[j].End)
+		}
+		if !i.Empty() {
+			result = append(result, i)
+		}
+	}
+	return Set{result}
+}
+
+// Split returns the integers in s before and from at.
+func (s Set) Split(at int) (Set, Set) {
+	below := Union(Interval{s.minStart(), at})
+	return s.Intersect(below), s.Difference(below)
+}
+
+func (s Set) minStart() int {
+	if s.Empty() {
+		return 0
+	}
+	return s.intervals[0].Start
+}
+
+func (s Set) String() string {
+	return fmt.Sprint(s.intervals)
+}
@@ -0,0 +1,130 @@
+package interval
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInterval(t *testing.T) {
+	i := Length(5, 3)
+	if i != New(5, 8) || i.Len() != 3 || !i.Contains(7) || i.Contains(8) {
+		t.Errorf("Length(5, 3) = %v, want [5, 8)", i)
+	}
+	if got := i.Intersect(New(0, 6)); got != New(5, 6) {
+		t.Errorf("Intersect() = %v, want [5, 6)", got)
+	}
+	if i.Overlaps(New(8, 10)) || !i.Overlaps(New(7, 10)) {
+		t.Errorf("Overlaps() wrong at the end of %v", i)
+	}
+	if got := i.Shift(-5); got != New(0, 3) {
+		t.Errorf("Shift(-5) = %v, want [0, 3)", got)
+	}
+	if got := New(4, 2).Len(); got != 0 {
+		t.Errorf("New(4, 2).Len() = %v, want 0", got)
+	}
+}
+
+func TestIntervalSplit(t *testing.T) {
+	tests := []struct {
+		at            int
+		before, after Interval
+	}{
+		{6, New(5, 6), New(6, 8)},
+		{0, New(5, 5), New(5, 8)},
+		{10, New(5, 8), New(8, 8)},
+	}
+	for _, tt := range tests {
+		before, after := New(5, 8).Split(tt.at)
+		if before != tt.before || after != tt.after {
+			t.Errorf("Split(%d) = %v, %v, want %v, %v", tt.at, before, after, tt.before, tt.after)
+		}
+	}
+}
+
+func TestIntervalDifference(t *testing.T) {
+	tests := []struct {
+		o    Interval
+		want []Interval
+	}{
+		{New(3, 5), []Interval{New(0, 3), New(5, 10)}},
+		{New(-5, 5), []Interval{New(5, 10)}},
+		{New(5, 15), []Interval{New(0, 5)}},
+		{New(20, 30), []Interval{New(0, 10)}},
+		{New(-1, 11), []Interval{}},
+	}
+	for _, tt := range tests {
+		if got := New(0, 10).Difference(tt.o); !slices.Equal(got, tt.want) {
+			t.Errorf("Difference(%v) = %v, want %v", tt.o, got, tt.want)
+		}
+	}
+}
+
+func TestUnion(t *testing.T) {
+	s := Union(New(10, 12), New(0, 3), New(3, 5), New(7, 7), New(11, 15))
+	want := []Interval{New(0, 5), New(10, 15)}
+	if got := s.Intervals(); !slices.Equal(got, want) {
+		t.Errorf("Union() = %v, want %v", got, want)
+	}
+	if s.Len() != 10 {
+		t.Errorf("Len() = %v, want 10", s.Len())
+	}
+	if min, ok := s.Min(); !ok || min != 0 {
+		t.Errorf("Min() = %v, %v, want 0", min, ok)
+	}
+	if _, ok := (Set{}).Min(); ok {
+		t.Errorf("Min() of empty set ok")
+	}
+	for n, want := range map[int]bool{-1: false, 0: true, 4: true, 5: false, 9: false, 10: true, 14: true, 15: false} {
+		if got := s.Contains(n); got != want {
+			t.Errorf("Contains(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a := Union(New(0, 10), New(20, 30))
+	b := Union(New(5, 25), New(28, 40))
+
+	tests := []struct {
+		name string
+		got  Set
+		want []Interval
+	}{
+		{"union", a.Union(b), []Interval{New(0, 40)}},
+		{"intersect", a.Intersect(b), []Interval{New(5, 10), New(20, 25), New(28, 30)}},
+		{"difference", a.Difference(b), []Interval{New(0, 5), New(25, 28)}},
+		{"reverse difference", b.Difference(a), []Interval{New(10, 20), New(30, 40)}},
+		{"difference with empty", a.Difference(Set{}), a.Intervals()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.Intervals(); !slices.Equal(got, tt.want) {
+				t.Errorf("%v = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	before, after := a.Split(25)
+	if !slices.Equal(before.Intervals(), []Interval{New(0, 10), New(20, 25)}) ||
+		!slices.Equal(after.Intervals(), []Interval{New(25, 30)}) {
+		t.Errorf("Split(25) = %v, %v", before, after)
+	}
+}
+
+func TestSetOperationsMatchMembership(t *testing.T) {
+	a := Union(New(0, 4), New(6, 9), New(12, 13))
+	b := Union(New(2, 7), New(8, 14))
+
+	for n := -2; n < 16; n++ {
+		inA, inB := a.Contains(n), b.Contains(n)
+		if got := a.Union(b).Contains(n); got != (inA || inB) {
+			t.Errorf("union contains %d = %v", n, got)
+		}
+		if got := a.Intersect(b).Contains(n); got != (inA && inB) {
+			t.Errorf("intersection contains %d = %v", n, got)
+		}
+		if got := a.Difference(b).Contains(n); got != (inA && !inB) {
+			t.Errorf("difference contains %d = %v", n, got)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Package numtheory has the number theory that cycle based puzzles need,
+// over *big.Int. No function modifies its arguments.
+package numtheory
+
+import (
+	"errors"
+	"math/big"
+)
+
+// GCD returns the non-negative greatest common divisor of a and b.
+func GCD(a, b *big.Int) *big.Int {
+	return new(big.Int).GCD(nil, nil, new(big.Int).Abs(a), new(big.Int).Abs(b))
+}
+
+// LCM returns the non-negative least common multiple of nums, 0 if any of
+// them is 0 and 1 if there are none.
+func LCM(nums ...*big.Int) *big.Int {
+	result := big.NewInt(1)
+	for _, n := range nums {
+		if n.Sign() == 0 {
+			return big.NewInt(0)
+		}
+		gcd := GCD(result, n)
+		result.Mul(result, new(big.Int).Div(new(big.Int).Abs(n), gcd))
+	}
+	return result
+}
+
+// ExtendedGCD returns g = gcd(a, b) along with x and y such that a*x + b*y = g.
+func ExtendedGCD(a, b *big.Int) (g, x, y *big.Int) {
+	oldR, r := new(big.Int).Set(a), new(big.Int).Set(b)
+	oldX, x := big.NewInt(1), big.NewInt(0)
+	oldY, y := big.NewInt(0), big.NewInt(1)
+
+	quotient := new(big.Int)
+	for r.Sign() != 0 {
+		quotient.Quo(oldR, r)
+		oldR, r = r, new(big.Int).Sub(oldR, new(big.Int).Mul(quotient, r))
+		oldX, x = x, new(big.Int).Sub(oldX, new(big.Int).Mul(quotient, x))
+		oldY, y = y, new(big.Int).Sub(oldY, new(big.Int).Mul(quotient, y))
+	}
+
+	if oldR.Sign() < 0 {
+		oldR.Neg(oldR)
+		oldX.Neg(oldX)
+		oldY.Neg(oldY)
+	}
+	return oldR, oldX, oldY
+}
+
+var ErrNoInverse = errors.New("numtheory: no modular inverse")
+
+// ModInverse returns x in [0, m) with a*x = 1 (mod m).
+func ModInverse(a, m *big.Int) (*big.Int, error) {
+	if m.Sign() <= 0 {
+		return nil, ErrNoInverse
+	}
+
+	g, x, _ := ExtendedGCD(new(big.Int).Mod(a, m), m)
+	if g.Cmp(big.NewInt(1)) != 0 {
+		return nil, ErrNoInverse
+	}
+	return x.Mod(x, m), nil
+}
+
+// Congruence is t = Residue (mod Modulus) for a positive Modulus.
+type Congruence struct {
+	Residue *big.Int
+	Modulus *big.Int
+}
+
+func NewCongruence(residue, modulus int64) Congruence {
+	return Congruence{big.NewInt(residue), big.NewInt(modulus)}
+}
+
+// Holds reports whether t satisfies the congruence.
+func (c Congruence) Holds(t *big.Int) bool {
+	diff := new(big.Int).Sub(t, c.Residue)
+	return diff.Mod(diff, c.Modulus).Sign() == 0
+}
+
+// AtLeast returns the smallest t >= min satisfying the congruence. It handles
+// congruences that only hold from some offset on, such as the hits of a walk
+// once it has entered its cycle.
+func (c Congruence) AtLeast(min *big.Int) *big.Int {
+	// t = min + ((Residue - min) mod Modulus)
+	offset := new(big.Int).Sub(c.Residue, min)
+	offset.Mod(offset, c.Modulus)
+	return offset.Add(offset, min)
+}
+
+// CRT solves a system of congruences whose moduli need not be coprime. It
+// returns the single congruence, modulo the LCM of the moduli, satisfied by
+// exactly the solutions of the system, or false when there are none.
+func CRT(congruences ...Congruence) (Congruence, bool) {
+	result := NewCongruence(0, 1)
+	for _, c := range congruences {
+		var ok bool
+		if result, ok = combine(result, c); !ok {
+			return Congruence{}, false
+		}
+	}
+	return result, true
+}
+
+// combine solves t = r1 (mod m1), t = r2 (mod m2). Writing t = r1 + m1*k, we
+// need m1*k = r2 - r1 (mod m2), solvable iff g = gcd(m1, m2) divides r2 - r1,
+// with k = (r2 - r1)/g * inverse(m1/g) (mod m2/g).
+func combine(a, b Congruence) (Congruence, bool) {
+	g, p, _ := ExtendedGCD(a.Modulus, b.Modulus)
+
+	diff := new(big.Int).Sub(b.Residue, a.Residue)
+	quotient, remainder := new(big.Int).QuoRem(diff, g, new(big.Int))
+	if remainder.Sign() != 0 {
+		return Congruence{}, false
+	}
+
+	// p is the inverse of m1/g modulo m2/g by Bezout's identity.
+	m2g := new(big.Int).Quo(b.Modulus, g)
+	k := quotient.Mul(quotient, p)
+	k.Mod(k, m2g)
+
+	modulus := new(big.Int).Mul(a.Modulus, m2g)
+	residue := k.Mul(k, a.Modulus)
+	residue.Add(residue, a.Residue)
+	residue.Mod(residue, modulus)
+
+	return Congruence{residue, modulus}, true
+}
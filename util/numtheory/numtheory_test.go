@@ -0,0 +1,107 @@
+package numtheory
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGCDAndLCM(t *testing.T) {
+	a, b := big.NewInt(12), big.NewInt(-18)
+	if got := GCD(a, b); got.Int64() != 6 {
+		t.Errorf("GCD(12, -18) = %v, want 6", got)
+	}
+	if got := LCM(a, b, big.NewInt(5)); got.Int64() != 180 {
+		t.Errorf("LCM(12, -18, 5) = %v, want 180", got)
+	}
+	if a.Int64() != 12 || b.Int64() != -18 {
+		t.Errorf("arguments modified to %v, %v", a, b)
+	}
+	if got := LCM(); got.Int64() != 1 {
+		t.Errorf("LCM() = %v, want 1", got)
+	}
+}
+
+func TestExtendedGCD(t *testing.T) {
+	for _, tt := range [][2]int64{{240, 46}, {46, 240}, {-7, 3}, {0, 5}, {17, 0}} {
+		a, b := big.NewInt(tt[0]), big.NewInt(tt[1])
+		g, x, y := ExtendedGCD(a, b)
+
+		sum := new(big.Int).Add(new(big.Int).Mul(a, x), new(big.Int).Mul(b, y))
+		if g.Cmp(GCD(a, b)) != 0 || sum.Cmp(g) != 0 {
+			t.Errorf("ExtendedGCD(%v, %v) = %v, %v, %v, want a*x + b*y = gcd", a, b, g, x, y)
+		}
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	if got, err := ModInverse(big.NewInt(3), big.NewInt(11)); err != nil || got.Int64() != 4 {
+		t.Errorf("ModInverse(3, 11) = %v, %v, want 4", got, err)
+	}
+	if got, err := ModInverse(big.NewInt(-3), big.NewInt(11)); err != nil || got.Int64() != 7 {
+		t.Errorf("ModInverse(-3, 11) = %v, %v, want 7", got, err)
+	}
+	if _, err := ModInverse(big.NewInt(6), big.NewInt(9)); err == nil {
+		t.Errorf("ModInverse(6, 9) error = nil, want ErrNoInverse")
+	}
+}
+
+func TestCRT(t *testing.T) {
+	tests := []struct {
+		name        string
+		congruences []Congruence
+		want        Congruence
+		wantOk      bool
+	}{
+		{
+			name:        "coprime",
+			congruences: []Congruence{NewCongruence(2, 3), NewCongruence(3, 5), NewCongruence(2, 7)},
+			want:        NewCongruence(23, 105),
+			wantOk:      true,
+		},
+		{
+			name:        "not coprime",
+			congruences: []Congruence{NewCongruence(2, 6), NewCongruence(8, 10)},
+			want:        NewCongruence(8, 30),
+			wantOk:      true,
+		},
+		{
+			name:        "no solution",
+			congruences: []Congruence{NewCongruence(1, 4), NewCongruence(2, 6)},
+			wantOk:      false,
+		},
+		{
+			name:        "residues outside the modulus",
+			congruences: []Congruence{NewCongruence(-1, 4), NewCongruence(17, 6)},
+			want:        NewCongruence(11, 12),
+			wantOk:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CRT(tt.congruences...)
+			if ok != tt.wantOk {
+				t.Fatalf("CRT() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !ok {
+				return
+			}
+			if got.Residue.Cmp(tt.want.Residue) != 0 || got.Modulus.Cmp(tt.want.Modulus) != 0 {
+				t.Errorf("CRT() = %v mod %v, want %v mod %v", got.Residue, got.Modulus, tt.want.Residue, tt.want.Modulus)
+			}
+			for _, c := range tt.congruences {
+				if !c.Holds(got.Residue) {
+					t.Errorf("%v does not satisfy %v mod %v", got.Residue, c.Residue, c.Modulus)
+				}
+			}
+		})
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	c := NewCongruence(2, 5)
+	for _, tt := range [][2]int64{{0, 2}, {2, 2}, {3, 7}, {100, 102}, {-10, -8}} {
+		if got := c.AtLeast(big.NewInt(tt[0])); got.Int64() != tt[1] {
+			t.Errorf("AtLeast(%d) = %v, want %d", tt[0], got, tt[1])
+		}
+	}
+}